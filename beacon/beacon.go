@@ -0,0 +1,92 @@
+// Package beacon implements a drand-style threshold random beacon.
+//
+// In every round the holders of the shares of the group key sign the message of the round, threshold of
+// the partial signatures are recombined into the signature of the group key, and the randomness of the
+// round is the SHA-256 of that signature. BLS signatures are unique, so the randomness is known to no one
+// before threshold of the participants have signed and can be verified by anyone with the group key.
+//
+// Chained beacons sign SHA-256(previous signature || round), where the previous signature of the first
+// round is the genesis seed, so verifying a round needs the previous one. Unchained beacons sign
+// SHA-256(round), which allows computing the message of any future round, e.g. for timelock encryption.
+//
+// The messages are signed under the dedicated domain separation tag CiphersuiteID, so beacons are not
+// signatures of the group key under any other scheme and ordinary signatures are not beacons
+package beacon
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+
+	"github.com/0xPolygon/bnsnark1/core"
+)
+
+// CiphersuiteID identifies the beacon signatures and is the domain separation tag of their messages
+const CiphersuiteID = "BLS_BEACON_BN254G1_XMD:SHA-256_FT_RO_"
+
+var scheme = core.MustNewScheme(CiphersuiteID, []byte(CiphersuiteID), core.HashToG107WithDST)
+
+// errEmptyPreviousSignature is returned for a chained message with an empty previous signature,
+// which would be the message of the unchained round
+var errEmptyPreviousSignature = errors.New("previous signature of a chained beacon must not be empty")
+
+// Beacon is the signature of the group key for a round
+type Beacon struct {
+	Round uint64
+	// PreviousSignature is the serialized signature of the previous round, or the genesis seed for
+	// the first round. It is nil for unchained beacons
+	PreviousSignature []byte
+	Signature         *core.Signature
+}
+
+// Message returns the message signed in the round. It is unchained if prevSig is nil,
+// an empty prevSig is rejected
+func Message(round uint64, prevSig []byte) ([]byte, error) {
+	if prevSig != nil && len(prevSig) == 0 {
+		return nil, errEmptyPreviousSignature
+	}
+
+	h := sha256.New()
+	_, _ = h.Write(prevSig)
+	_ = binary.Write(h, binary.BigEndian, round)
+
+	return h.Sum(nil), nil
+}
+
+// VerifyBeacon checks the signature of the round chained to the previous signature against the group key.
+// A nil prevSig verifies an unchained beacon
+func VerifyBeacon(groupPK *core.PublicKey, round uint64, prevSig []byte, sig *core.Signature) bool {
+	message, err := Message(round, prevSig)
+	if err != nil {
+		return false
+	}
+
+	return scheme.Verify(sig, groupPK, message)
+}
+
+// VerifyUnchainedBeacon checks the signature of the unchained round against the group key
+func VerifyUnchainedBeacon(groupPK *core.PublicKey, round uint64, sig *core.Signature) bool {
+	return VerifyBeacon(groupPK, round, nil, sig)
+}
+
+// Verify checks the beacon against the group key
+func (b *Beacon) Verify(groupPK *core.PublicKey) bool {
+	return VerifyBeacon(groupPK, b.Round, b.PreviousSignature, b.Signature)
+}
+
+// Randomness returns the 32 bytes of randomness of the round, the SHA-256 of the signature,
+// or nil if the beacon has no signature
+func (b *Beacon) Randomness() []byte {
+	if b.Signature == nil {
+		return nil
+	}
+
+	raw, err := b.Signature.Marshal()
+	if err != nil {
+		return nil
+	}
+
+	h := sha256.Sum256(raw)
+
+	return h[:]
+}
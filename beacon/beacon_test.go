@@ -0,0 +1,274 @@
+package beacon
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/0xPolygon/bnsnark1/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testThreshold = 3
+	testTotal     = 5
+	testRounds    = 4
+)
+
+// network delivers the partial signatures of every node to all nodes in random order
+type network struct {
+	nodes   []*Node
+	offline map[int]bool
+	rng     *rand.Rand
+}
+
+type message struct {
+	round uint64
+	share *core.SignatureShare
+}
+
+func newNetwork(t *testing.T, unchained bool) (*network, *core.PublicKey) {
+	t.Helper()
+
+	key, err := core.GenerateBlsKey()
+	require.NoError(t, err)
+
+	shares, err := core.SplitPrivateKey(key, testThreshold, testTotal)
+	require.NoError(t, err)
+
+	cfg := Config{
+		GroupKey:     key.PublicKey(),
+		PublicShares: core.CollectPublicKeyShares(shares),
+		Threshold:    testThreshold,
+		Unchained:    unchained,
+		GenesisSeed:  []byte("genesis"),
+	}
+
+	net := &network{offline: make(map[int]bool), rng: rand.New(rand.NewSource(1))}
+
+	for _, share := range shares {
+		node, err := NewNode(cfg, share)
+		require.NoError(t, err)
+
+		net.nodes = append(net.nodes, node)
+	}
+
+	return net, key.PublicKey()
+}
+
+// runRound makes every online node sign its next round and delivers the partial signatures
+func (net *network) runRound(t *testing.T) {
+	t.Helper()
+
+	var messages []message
+
+	for i, node := range net.nodes {
+		if net.offline[i] {
+			continue
+		}
+
+		round, share, err := node.PartialSign()
+		require.NoError(t, err)
+
+		messages = append(messages, message{round: round, share: share})
+	}
+
+	net.rng.Shuffle(len(messages), func(i, j int) { messages[i], messages[j] = messages[j], messages[i] })
+
+	for _, msg := range messages {
+		for i, node := range net.nodes {
+			if net.offline[i] {
+				continue
+			}
+
+			_, err := node.AddPartial(msg.round, msg.share)
+			require.NoError(t, err)
+		}
+	}
+}
+
+func TestBeacon_Chained(t *testing.T) {
+	t.Parallel()
+
+	net, groupKey := newNetwork(t, false)
+
+	// the network tolerates total - threshold offline nodes
+	net.offline[1], net.offline[3] = true, true
+
+	prevSig := []byte("genesis")
+
+	for round := uint64(1); round <= testRounds; round++ {
+		net.runRound(t)
+
+		latest := net.nodes[0].Latest()
+		require.Equal(t, round, latest.Round)
+
+		assert.True(t, VerifyBeacon(groupKey, round, prevSig, latest.Signature))
+		assert.False(t, VerifyBeacon(groupKey, round+1, prevSig, latest.Signature))
+		assert.False(t, VerifyUnchainedBeacon(groupKey, round, latest.Signature))
+		assert.Len(t, latest.Randomness(), 32)
+
+		for _, i := range []int{2, 4} {
+			assert.Equal(t, latest.Randomness(), net.nodes[i].Latest().Randomness())
+		}
+
+		raw, err := latest.Signature.Marshal()
+		require.NoError(t, err)
+
+		prevSig = raw
+	}
+
+	// offline nodes have not progressed
+	assert.Equal(t, uint64(0), net.nodes[1].Latest().Round)
+	assert.Nil(t, net.nodes[1].Latest().Randomness())
+
+	first, ok := net.nodes[0].Beacon(1)
+	require.True(t, ok)
+	assert.True(t, first.Verify(groupKey))
+
+	_, ok = net.nodes[0].Beacon(testRounds + 1)
+	assert.False(t, ok)
+}
+
+func TestBeacon_Unchained(t *testing.T) {
+	t.Parallel()
+
+	net, groupKey := newNetwork(t, true)
+
+	for round := uint64(1); round <= testRounds; round++ {
+		net.runRound(t)
+
+		latest := net.nodes[0].Latest()
+		require.Equal(t, round, latest.Round)
+		assert.Nil(t, latest.PreviousSignature)
+		assert.True(t, VerifyUnchainedBeacon(groupKey, round, latest.Signature))
+		assert.True(t, latest.Verify(groupKey))
+
+		for _, node := range net.nodes {
+			assert.Equal(t, latest.Randomness(), node.Latest().Randomness())
+		}
+	}
+}
+
+func TestBeacon_DomainSeparation(t *testing.T) {
+	t.Parallel()
+
+	key, err := core.GenerateBlsKey()
+	require.NoError(t, err)
+
+	message, err := Message(1, nil)
+	require.NoError(t, err)
+
+	beacon, err := scheme.Sign(key, message)
+	require.NoError(t, err)
+	assert.True(t, VerifyUnchainedBeacon(key.PublicKey(), 1, beacon))
+
+	// a beacon is not an ordinary signature of its message and an ordinary signature is not a beacon
+	signature, err := key.Sign(message)
+	require.NoError(t, err)
+
+	assert.False(t, beacon.Verify(key.PublicKey(), message))
+	assert.False(t, VerifyUnchainedBeacon(key.PublicKey(), 1, signature))
+
+	// an empty previous signature would make the chained message the unchained one
+	_, err = Message(1, []byte{})
+	assert.ErrorIs(t, err, errEmptyPreviousSignature)
+	assert.False(t, VerifyBeacon(key.PublicKey(), 1, []byte{}, beacon))
+}
+
+func TestBeacon_BufferedPartials(t *testing.T) {
+	t.Parallel()
+
+	net, groupKey := newNetwork(t, false)
+
+	// nodes 0, 1 and 2 complete the first round while node 4 does not receive their partial signatures
+	var first, second []message
+
+	for _, i := range []int{0, 1, 2} {
+		round, share, err := net.nodes[i].PartialSign()
+		require.NoError(t, err)
+
+		first = append(first, message{round: round, share: share})
+	}
+
+	for _, msg := range first {
+		for _, i := range []int{0, 1, 2} {
+			_, err := net.nodes[i].AddPartial(msg.round, msg.share)
+			require.NoError(t, err)
+		}
+	}
+
+	// the partial signatures of the second round arrive at node 4 before the ones of the first round
+	for _, i := range []int{0, 1, 2} {
+		round, share, err := net.nodes[i].PartialSign()
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), round)
+
+		second = append(second, message{round: round, share: share})
+	}
+
+	lagging := net.nodes[4]
+
+	// a partial signature of an index already buffered for the round is dropped
+	invalid := &core.SignatureShare{Index: second[0].share.Index, Signature: first[0].share.Signature}
+
+	for _, msg := range append(second, second[1], message{round: 2, share: invalid}) {
+		completed, err := lagging.AddPartial(msg.round, msg.share)
+		require.NoError(t, err)
+		assert.Empty(t, completed)
+	}
+
+	require.Len(t, lagging.pending[2], len(second))
+	assert.Same(t, second[0].share, lagging.pending[2][second[0].share.Index])
+
+	var completed []*Beacon
+
+	for _, msg := range first {
+		res, err := lagging.AddPartial(msg.round, msg.share)
+		require.NoError(t, err)
+
+		completed = append(completed, res...)
+	}
+
+	// the last partial signature of the first round completes both rounds
+	require.Len(t, completed, 2)
+	assert.Equal(t, uint64(2), lagging.Latest().Round)
+	assert.True(t, completed[1].Verify(groupKey))
+}
+
+func TestBeacon_InvalidPartials(t *testing.T) {
+	t.Parallel()
+
+	net, _ := newNetwork(t, false)
+
+	node := net.nodes[0]
+
+	round, share, err := net.nodes[1].PartialSign()
+	require.NoError(t, err)
+
+	// the partial signature is checked against the public key share of its index
+	_, err = node.AddPartial(round, &core.SignatureShare{Index: 3, Signature: share.Signature})
+	assert.ErrorIs(t, err, errInvalidPartial)
+
+	_, err = node.AddPartial(round, &core.SignatureShare{Index: 9, Signature: share.Signature})
+	assert.ErrorIs(t, err, errUnknownShare)
+
+	_, err = node.AddPartial(round+maxFutureRounds, share)
+	assert.ErrorIs(t, err, errFutureRound)
+
+	_, err = node.AddPartial(round, nil)
+	assert.ErrorIs(t, err, errInvalidPartial)
+
+	_, err = NewNode(Config{GroupKey: node.cfg.GroupKey, PublicShares: node.cfg.PublicShares, Threshold: testThreshold},
+		node.share)
+	assert.ErrorIs(t, err, errMissingGenesis)
+
+	_, err = NewNode(node.cfg, &core.KeyShare{Index: 1, Key: net.nodes[1].share.Key})
+	assert.ErrorIs(t, err, errShareMismatch)
+
+	cfg := node.cfg
+	cfg.Threshold = testTotal + 1
+
+	_, err = NewNode(cfg, node.share)
+	assert.ErrorIs(t, err, errInvalidThreshold)
+}
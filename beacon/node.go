@@ -0,0 +1,236 @@
+package beacon
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/bnsnark1/core"
+)
+
+// maxFutureRounds bounds the rounds ahead of the latest one whose partial signatures are buffered
+const maxFutureRounds = 8
+
+var (
+	errInvalidThreshold = errors.New("threshold must be between 1 and the number of public key shares")
+	errMissingGenesis   = errors.New("chained beacon needs a genesis seed")
+	errShareMismatch    = errors.New("key share does not match its public key share")
+	errUnknownShare     = errors.New("partial signature of an unknown share")
+	errInvalidPartial   = errors.New("invalid partial signature")
+	errFutureRound      = errors.New("partial signature too far ahead of the latest round")
+)
+
+// Config describes the beacon network
+type Config struct {
+	// GroupKey verifies the beacons
+	GroupKey *core.PublicKey
+	// PublicShares are the public key shares of all participants
+	PublicShares []*core.PublicKeyShare
+	// Threshold is the number of partial signatures recombined into a beacon
+	Threshold int
+	// Unchained selects unchained beacons, GenesisSeed is the previous signature of the first chained round
+	Unchained   bool
+	GenesisSeed []byte
+}
+
+// Node is a participant of the beacon network, which signs the rounds with its key share
+// and recombines the partial signatures it receives. Rounds are completed in order starting from 1
+type Node struct {
+	cfg     Config
+	share   *core.KeyShare
+	pubs    map[uint64]*core.PublicKeyShare
+	beacons []*Beacon
+	latest  *Beacon
+
+	// valid are the verified partial signatures of the next round by index, pending the unverified ones
+	// of later rounds by round and index. Indices are those of the committee, so every round holds at most
+	// one partial signature per participant
+	valid   map[uint64]*core.SignatureShare
+	pending map[uint64]map[uint64]*core.SignatureShare
+}
+
+// NewNode creates the node signing with the key share
+func NewNode(cfg Config, share *core.KeyShare) (*Node, error) {
+	if cfg.Threshold < 1 || cfg.Threshold > len(cfg.PublicShares) {
+		return nil, errInvalidThreshold
+	}
+
+	if !cfg.Unchained && len(cfg.GenesisSeed) == 0 {
+		return nil, errMissingGenesis
+	}
+
+	pubs := make(map[uint64]*core.PublicKeyShare, len(cfg.PublicShares))
+	for _, pub := range cfg.PublicShares {
+		pubs[pub.Index] = pub
+	}
+
+	own, ok := pubs[share.Index]
+	if !ok || !bytes.Equal(own.Key.Marshal(), share.Key.PublicKey().Marshal()) {
+		return nil, errShareMismatch
+	}
+
+	return &Node{
+		cfg:     cfg,
+		share:   share,
+		pubs:    pubs,
+		latest:  &Beacon{Round: 0},
+		valid:   make(map[uint64]*core.SignatureShare),
+		pending: make(map[uint64]map[uint64]*core.SignatureShare),
+	}, nil
+}
+
+// PartialSign signs the round following the latest beacon with the key share of the node
+func (n *Node) PartialSign() (uint64, *core.SignatureShare, error) {
+	round := n.latest.Round + 1
+
+	message, err := Message(round, n.previousSignature())
+	if err != nil {
+		return 0, nil, err
+	}
+
+	signature, err := scheme.Sign(n.share.Key, message)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return round, &core.SignatureShare{Index: n.share.Index, Signature: signature}, nil
+}
+
+// AddPartial adds the partial signature of the round. Partial signatures of the next round are verified
+// immediately, the ones of later rounds are buffered until the node reaches their round, because chained
+// messages depend on the previous beacon. Only the first buffered partial signature of an index is kept,
+// if it turns out invalid the index has to send its partial signature again once its round is the next one.
+// Partial signatures of completed rounds are ignored.
+// It returns the beacons completed thanks to the partial signature
+func (n *Node) AddPartial(round uint64, share *core.SignatureShare) ([]*Beacon, error) {
+	if share == nil {
+		return nil, errInvalidPartial
+	}
+
+	if _, ok := n.pubs[share.Index]; !ok {
+		return nil, fmt.Errorf("%w: %d", errUnknownShare, share.Index)
+	}
+
+	switch {
+	case round <= n.latest.Round:
+		return nil, nil
+	case round > n.latest.Round+maxFutureRounds:
+		return nil, fmt.Errorf("%w: %d", errFutureRound, round)
+	case round > n.latest.Round+1:
+		n.addPending(round, share)
+
+		return nil, nil
+	}
+
+	if !n.addValid(share) {
+		return nil, fmt.Errorf("%w: round %d index %d", errInvalidPartial, round, share.Index)
+	}
+
+	var completed []*Beacon
+
+	for len(n.valid) >= n.cfg.Threshold {
+		beacon, err := n.complete()
+		if err != nil {
+			return completed, err
+		}
+
+		completed = append(completed, beacon)
+	}
+
+	return completed, nil
+}
+
+// Latest returns the latest beacon, round 0 before the first round is completed
+func (n *Node) Latest() *Beacon {
+	return n.latest
+}
+
+// Beacon returns the beacon of the round, if completed
+func (n *Node) Beacon(round uint64) (*Beacon, bool) {
+	if round == 0 || round > uint64(len(n.beacons)) {
+		return nil, false
+	}
+
+	return n.beacons[round-1], true
+}
+
+// addPending buffers the partial signature of a later round unless the index already has one
+func (n *Node) addPending(round uint64, share *core.SignatureShare) {
+	shares, ok := n.pending[round]
+	if !ok {
+		shares = make(map[uint64]*core.SignatureShare, len(n.pubs))
+		n.pending[round] = shares
+	}
+
+	if _, exists := shares[share.Index]; !exists {
+		shares[share.Index] = share
+	}
+}
+
+// addValid verifies the partial signature of the next round and keeps it
+func (n *Node) addValid(share *core.SignatureShare) bool {
+	if _, exists := n.valid[share.Index]; exists {
+		return true
+	}
+
+	message, err := Message(n.latest.Round+1, n.previousSignature())
+	if err != nil || !scheme.Verify(share.Signature, n.pubs[share.Index].Key, message) {
+		return false
+	}
+
+	n.valid[share.Index] = share
+
+	return true
+}
+
+// complete recombines threshold of the valid partial signatures into the beacon of the next round
+// and moves on to the following round
+func (n *Node) complete() (*Beacon, error) {
+	round := n.latest.Round + 1
+
+	shares := make([]*core.SignatureShare, 0, n.cfg.Threshold)
+	for _, share := range n.valid {
+		if len(shares) == n.cfg.Threshold {
+			break
+		}
+
+		shares = append(shares, share)
+	}
+
+	signature, err := core.RecoverSignature(shares)
+	if err != nil {
+		return nil, err
+	}
+
+	beacon := &Beacon{Round: round, PreviousSignature: n.previousSignature(), Signature: signature}
+	if !beacon.Verify(n.cfg.GroupKey) {
+		return nil, fmt.Errorf("recombined signature of round %d does not verify against the group key", round)
+	}
+
+	n.latest = beacon
+	n.beacons = append(n.beacons, beacon)
+	n.valid = make(map[uint64]*core.SignatureShare)
+
+	// invalid buffered partial signatures are dropped
+	for _, share := range n.pending[round+1] {
+		n.addValid(share)
+	}
+
+	delete(n.pending, round+1)
+
+	return beacon, nil
+}
+
+func (n *Node) previousSignature() []byte {
+	if n.cfg.Unchained {
+		return nil
+	}
+
+	if n.latest.Signature == nil {
+		return n.cfg.GenesisSeed
+	}
+
+	raw, _ := n.latest.Signature.Marshal()
+
+	return raw
+}
@@ -0,0 +1,547 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/0xPolygon/bnsnark1/core"
+	"github.com/0xPolygon/bnsnark1/hd"
+	"github.com/0xPolygon/bnsnark1/keystore"
+)
+
+// Private keys are stored as the hex of PrivateKey.MarshalJSON or as EIP-2335 keystores, public keys and
+// signatures as hex, compressed or not. Secrets, private keys, mnemonics and passwords, are only read from files
+// so they do not show up in the arguments of the process. Messages are read from files with -message-file,
+// public keys and signatures when they are given as @ followed by the path of the file
+
+func runKeygen(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("keygen", flag.ContinueOnError)
+	out := fs.String("out", "", "file to write the key to instead of stdout, it must not exist")
+	force := fs.Bool("force", false, "overwrite the file of -out if it exists")
+	passwordFile := fs.String("password-file", "", "write an encrypted keystore with the password of the file")
+	mnemonicFile := fs.String("mnemonic-file", "", "derive the key from the BIP-39 mnemonic of the file")
+	passphraseFile := fs.String("passphrase-file", "", "BIP-39 passphrase of the mnemonic")
+	path := fs.String("path", "m/12381/60/0/0", "derivation path of the key from the mnemonic")
+	kdf := fs.String("kdf", keystore.ScryptKDF, "key derivation function of the keystore: scrypt or pbkdf2")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		key *core.PrivateKey
+		err error
+	)
+
+	derived := *mnemonicFile != ""
+
+	if derived {
+		words, err := os.ReadFile(*mnemonicFile)
+		if err != nil {
+			return err
+		}
+
+		var passphrase string
+
+		if *passphraseFile != "" {
+			if passphrase, err = readPassword(*passphraseFile); err != nil {
+				return err
+			}
+		}
+
+		key, err = hd.DeriveKeyFromMnemonic(strings.TrimSpace(string(words)), passphrase, *path)
+		if err != nil {
+			return err
+		}
+	} else if key, err = core.GenerateBlsKey(); err != nil {
+		return err
+	}
+
+	if *passwordFile == "" {
+		raw, err := key.MarshalJSON()
+		if err != nil {
+			return err
+		}
+
+		return writeKey(stdout, *out, hex.EncodeToString(raw), *force)
+	}
+
+	password, err := readPassword(*passwordFile)
+	if err != nil {
+		return err
+	}
+
+	opts := &keystore.Options{KDF: *kdf}
+	if derived {
+		opts.Path = *path
+	}
+
+	encrypted, err := keystore.EncryptWithOptions(key, password, opts)
+	if err != nil {
+		return err
+	}
+
+	return writeKey(stdout, *out, string(encrypted), *force)
+}
+
+func runPubkey(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("pubkey", flag.ContinueOnError)
+	keyFile := fs.String("key-file", "", "file of the private key or keystore")
+	passwordFile := fs.String("password-file", "", "password of the keystore")
+	compressed := fs.Bool("compressed", false, "print the compressed public key")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	key, err := readPrivateKey(*keyFile, *passwordFile)
+	if err != nil {
+		return err
+	}
+
+	if *compressed {
+		return writeOutput(stdout, hex.EncodeToString(key.PublicKey().MarshalCompressed()))
+	}
+
+	return writeOutput(stdout, hex.EncodeToString(key.PublicKey().Marshal()))
+}
+
+func runSign(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("sign", flag.ContinueOnError)
+	keyFile := fs.String("key-file", "", "file of the private key or keystore")
+	passwordFile := fs.String("password-file", "", "password of the keystore")
+	message := fs.String("message", "", "message to sign")
+	messageFile := fs.String("message-file", "", "file of the message to sign")
+	messageHex := fs.Bool("message-hex", false, "the message is hex encoded")
+	compressed := fs.Bool("compressed", false, "print the compressed signature")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	key, err := readPrivateKey(*keyFile, *passwordFile)
+	if err != nil {
+		return err
+	}
+
+	msg, err := readMessage(fs, *message, *messageFile, *messageHex)
+	if err != nil {
+		return err
+	}
+
+	signature, err := key.Sign(msg)
+	if err != nil {
+		return err
+	}
+
+	return writeSignature(stdout, signature, *compressed)
+}
+
+func runVerify(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	pubkeyFlag := fs.String("pubkey", "", "public key of the signer")
+	signatureFlag := fs.String("signature", "", "signature of the message")
+	message := fs.String("message", "", "signed message")
+	messageFile := fs.String("message-file", "", "file of the signed message")
+	messageHex := fs.Bool("message-hex", false, "the message is hex encoded")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	pubkey, err := readPublicKey(*pubkeyFlag)
+	if err != nil {
+		return err
+	}
+
+	signature, err := readSignature(*signatureFlag)
+	if err != nil {
+		return err
+	}
+
+	msg, err := readMessage(fs, *message, *messageFile, *messageHex)
+	if err != nil {
+		return err
+	}
+
+	if !signature.Verify(pubkey, msg) {
+		return errInvalidSignature
+	}
+
+	return writeOutput(stdout, "valid")
+}
+
+func runAggregateSigs(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("aggregate-sigs", flag.ContinueOnError)
+	compressed := fs.Bool("compressed", false, "print the compressed signature")
+
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: bnsnark1 aggregate-sigs [-compressed] <signature or @file>...")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		return errors.New("no signatures to aggregate")
+	}
+
+	signatures := make([]*core.Signature, fs.NArg())
+
+	for i, arg := range fs.Args() {
+		signature, err := readSignature(arg)
+		if err != nil {
+			return err
+		}
+
+		signatures[i] = signature
+	}
+
+	return writeSignature(stdout, core.AggregateSignatures(signatures), *compressed)
+}
+
+func runAggregatePubkeys(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("aggregate-pubkeys", flag.ContinueOnError)
+	compressed := fs.Bool("compressed", false, "print the compressed public key")
+
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: bnsnark1 aggregate-pubkeys [-compressed] <public key or @file>...")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		return errors.New("no public keys to aggregate")
+	}
+
+	pubkeys := make([]*core.PublicKey, fs.NArg())
+
+	for i, arg := range fs.Args() {
+		pubkey, err := readPublicKey(arg)
+		if err != nil {
+			return err
+		}
+
+		pubkeys[i] = pubkey
+	}
+
+	aggregated := core.AggregatePublicKeys(pubkeys)
+
+	if *compressed {
+		return writeOutput(stdout, hex.EncodeToString(aggregated.MarshalCompressed()))
+	}
+
+	return writeOutput(stdout, hex.EncodeToString(aggregated.Marshal()))
+}
+
+func runPop(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("pop", flag.ContinueOnError)
+	keyFile := fs.String("key-file", "", "file of the private key or keystore to prove the possession of")
+	passwordFile := fs.String("password-file", "", "password of the keystore")
+	pubkeyFlag := fs.String("pubkey", "", "public key to verify the proof against instead of generating one")
+	proofFlag := fs.String("proof", "", "proof of possession to verify")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *pubkeyFlag != "" {
+		pubkey, err := readPublicKey(*pubkeyFlag)
+		if err != nil {
+			return err
+		}
+
+		proof, err := readSignature(*proofFlag)
+		if err != nil {
+			return err
+		}
+
+		if !pubkey.VerifyPossession(proof) {
+			return errInvalidSignature
+		}
+
+		return writeOutput(stdout, "valid")
+	}
+
+	key, err := readPrivateKey(*keyFile, *passwordFile)
+	if err != nil {
+		return err
+	}
+
+	proof, err := key.ProvePossession()
+	if err != nil {
+		return err
+	}
+
+	return writeSignature(stdout, proof, false)
+}
+
+func runEncode(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("encode", flag.ContinueOnError)
+	format := fs.String("format", "hex", "output format: evm, hex or json")
+	pubkeyFlag := fs.String("pubkey", "", "public key to encode")
+	signatureFlag := fs.String("signature", "", "signature to encode")
+	compressed := fs.Bool("compressed", false, "encode compressed with the hex format")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var (
+		raw    []byte
+		coords []*big.Int
+	)
+
+	switch {
+	case *pubkeyFlag != "":
+		pubkey, err := readPublicKey(*pubkeyFlag)
+		if err != nil {
+			return err
+		}
+
+		big4 := pubkey.ToBigInt()
+		raw, coords = pubkey.ToEVM(), big4[:]
+
+		if *format == "hex" {
+			raw = pubkey.Marshal()
+			if *compressed {
+				raw = pubkey.MarshalCompressed()
+			}
+		}
+	case *signatureFlag != "":
+		signature, err := readSignature(*signatureFlag)
+		if err != nil {
+			return err
+		}
+
+		big2, err := signature.ToBigInt()
+		if err != nil {
+			return err
+		}
+
+		coords = big2[:]
+
+		switch {
+		case *format == "hex" && *compressed:
+			raw, err = signature.MarshalCompressed()
+		case *format == "hex":
+			raw, err = signature.Marshal()
+		default:
+			raw, err = signature.ToEVM()
+		}
+
+		if err != nil {
+			return err
+		}
+	default:
+		return errors.New("either -pubkey or -signature must be given")
+	}
+
+	switch *format {
+	case "evm":
+		return writeOutput(stdout, "0x"+hex.EncodeToString(raw))
+	case "hex":
+		return writeOutput(stdout, hex.EncodeToString(raw))
+	case "json":
+		// the coordinates as decimal strings, in the order of the uint256 array of a Solidity verifier
+		res := make([]string, len(coords))
+		for i, c := range coords {
+			res[i] = c.String()
+		}
+
+		encoded, err := json.Marshal(res)
+		if err != nil {
+			return err
+		}
+
+		return writeOutput(stdout, string(encoded))
+	default:
+		return fmt.Errorf("unknown format %q", *format)
+	}
+}
+
+// readValue returns the trimmed content of the file if value is @ followed by its path, otherwise the value itself
+func readValue(value string) (string, error) {
+	if value == "" {
+		return "", errors.New("missing value")
+	}
+
+	if strings.HasPrefix(value, "@") {
+		raw, err := os.ReadFile(value[1:])
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimSpace(string(raw)), nil
+	}
+
+	return value, nil
+}
+
+func readHex(value string) ([]byte, error) {
+	s, err := readValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
+
+func readPassword(path string) (string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(raw), "\r\n"), nil
+}
+
+func readPrivateKey(path, passwordFile string) (*core.PrivateKey, error) {
+	if path == "" {
+		return nil, errors.New("private key: missing -key-file")
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("private key: %w", err)
+	}
+
+	s := strings.TrimSpace(string(raw))
+
+	if strings.HasPrefix(s, "{") {
+		if passwordFile == "" {
+			return nil, errors.New("the private key is a keystore, -password-file must be given")
+		}
+
+		password, err := readPassword(passwordFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return keystore.Decrypt([]byte(s), password)
+	}
+
+	decoded, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, err
+	}
+
+	return core.UnmarshalPrivateKey(decoded)
+}
+
+func readPublicKey(value string) (*core.PublicKey, error) {
+	raw, err := readHex(value)
+	if err != nil {
+		return nil, fmt.Errorf("public key: %w", err)
+	}
+
+	return core.UnmarshalPublicKey(raw)
+}
+
+func readSignature(value string) (*core.Signature, error) {
+	raw, err := readHex(value)
+	if err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+
+	return core.UnmarshalSignature(raw)
+}
+
+// readMessage returns the bytes of the -message flag or of the file as they are, so the message may be empty.
+// Only hex messages are trimmed
+func readMessage(fs *flag.FlagSet, value, path string, isHex bool) ([]byte, error) {
+	s, isSet := value, false
+
+	fs.Visit(func(f *flag.Flag) {
+		isSet = isSet || f.Name == "message"
+	})
+
+	switch {
+	case isSet && path != "":
+		return nil, errors.New("message: only one of -message and -message-file can be given")
+	case path != "":
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("message: %w", err)
+		}
+
+		s = string(raw)
+	case !isSet:
+		return nil, errors.New("message: missing -message or -message-file")
+	}
+
+	if isHex {
+		return hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(s), "0x"))
+	}
+
+	return []byte(s), nil
+}
+
+func writeSignature(stdout io.Writer, signature *core.Signature, compressed bool) error {
+	marshal := signature.Marshal
+	if compressed {
+		marshal = signature.MarshalCompressed
+	}
+
+	raw, err := marshal()
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(stdout, hex.EncodeToString(raw))
+}
+
+// writeKey writes the key to stdout, or to the file readable only by the owner if there is one.
+// An existing file is only overwritten with force, and then made readable only by the owner as well
+func writeKey(stdout io.Writer, path string, data string, force bool) error {
+	if path == "" {
+		return writeOutput(stdout, data)
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_EXCL
+	if force {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	}
+
+	f, err := os.OpenFile(path, flags, 0o600)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("%s already exists, -force overwrites it", path)
+		}
+
+		return err
+	}
+
+	if err := f.Chmod(0o600); err != nil {
+		f.Close()
+
+		return err
+	}
+
+	if _, err := f.WriteString(data + "\n"); err != nil {
+		f.Close()
+
+		return err
+	}
+
+	return f.Close()
+}
+
+// writeOutput writes the data to stdout
+func writeOutput(stdout io.Writer, data string) error {
+	_, err := fmt.Fprintln(stdout, data)
+
+	return err
+}
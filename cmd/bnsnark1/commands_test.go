@@ -0,0 +1,259 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/0xPolygon/bnsnark1/core"
+	"github.com/0xPolygon/bnsnark1/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommands_SignVerify(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key")
+
+	runTest(t, "keygen", "-out", keyFile)
+
+	pubkey := runTest(t, "pubkey", "-key-file", keyFile)
+	signature := runTest(t, "sign", "-key-file", keyFile, "-message", "abc")
+
+	assert.Equal(t, "valid", runTest(t, "verify", "-pubkey", pubkey, "-signature", signature, "-message", "abc"))
+	assert.Equal(t, "valid", runTest(t, "verify", "-pubkey", pubkey, "-signature", signature, "-message", "616263", "-message-hex"))
+
+	err := run([]string{"verify", "-pubkey", pubkey, "-signature", signature, "-message", "abd"}, new(bytes.Buffer))
+	assert.ErrorIs(t, err, errInvalidSignature)
+
+	// compressed values are accepted as well, files are read from the paths following @
+	compressedSignature := runTest(t, "sign", "-key-file", keyFile, "-message", "abc", "-compressed")
+	assert.Len(t, compressedSignature, 2*core.G1CompressedSize)
+
+	pubkeyFile := filepath.Join(dir, "pubkey")
+	require.NoError(t, os.WriteFile(pubkeyFile, []byte(runTest(t, "pubkey", "-key-file", keyFile, "-compressed")+"\n"), 0o600))
+
+	assert.Equal(t, "valid",
+		runTest(t, "verify", "-pubkey", "@"+pubkeyFile, "-signature", compressedSignature, "-message", "abc"))
+
+	err = run([]string{"verify", "-pubkey", pubkeyFile, "-signature", signature, "-message", "abc"}, new(bytes.Buffer))
+	assert.Error(t, err)
+}
+
+func TestCommands_KeygenOut(t *testing.T) {
+	t.Parallel()
+
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("existing key\n"), 0o644))
+
+	// an existing key is not overwritten without -force
+	err := run([]string{"keygen", "-out", keyFile}, new(bytes.Buffer))
+	assert.Error(t, err)
+
+	raw, err := os.ReadFile(keyFile)
+	require.NoError(t, err)
+	assert.Equal(t, "existing key\n", string(raw))
+
+	runTest(t, "keygen", "-out", keyFile, "-force")
+
+	info, err := os.Stat(keyFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	raw, err = os.ReadFile(keyFile)
+	require.NoError(t, err)
+	assert.NotEqual(t, "existing key\n", string(raw))
+}
+
+func TestCommands_Message(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key")
+
+	runTest(t, "keygen", "-out", keyFile)
+
+	pubkey := runTest(t, "pubkey", "-key-file", keyFile)
+
+	// a message naming a file is the message itself, not the content of the file
+	messageFile := filepath.Join(dir, "message")
+	require.NoError(t, os.WriteFile(messageFile, []byte(" abc\n"), 0o600))
+
+	signature := runTest(t, "sign", "-key-file", keyFile, "-message", messageFile)
+	assert.Equal(t, "valid", runTest(t, "verify", "-pubkey", pubkey, "-signature", signature, "-message", messageFile))
+
+	err := run([]string{"verify", "-pubkey", pubkey, "-signature", signature, "-message-file", messageFile},
+		new(bytes.Buffer))
+	assert.ErrorIs(t, err, errInvalidSignature)
+
+	// the content of a message file is not trimmed
+	signature = runTest(t, "sign", "-key-file", keyFile, "-message-file", messageFile)
+	assert.Equal(t, "valid", runTest(t, "verify", "-pubkey", pubkey, "-signature", signature, "-message", " abc\n"))
+
+	// the message may be empty
+	signature = runTest(t, "sign", "-key-file", keyFile, "-message", "")
+	assert.Equal(t, "valid", runTest(t, "verify", "-pubkey", pubkey, "-signature", signature, "-message-hex", "-message", ""))
+
+	for _, args := range [][]string{
+		{"sign", "-key-file", keyFile},
+		{"sign", "-key-file", keyFile, "-message", "abc", "-message-file", messageFile},
+		{"sign", "-message", "abc"},
+		// secrets are not accepted as arguments
+		{"sign", "-key", keyFile, "-message", "abc"},
+		{"keygen", "-mnemonic", strings.Repeat("abandon ", 11) + "about"},
+	} {
+		assert.Error(t, run(args, new(bytes.Buffer)), args)
+	}
+}
+
+func TestCommands_Keystore(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	passwordFile := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("password\n"), 0o600))
+
+	mnemonicFile := filepath.Join(dir, "mnemonic")
+	require.NoError(t, os.WriteFile(mnemonicFile, []byte(strings.Repeat("abandon ", 11)+"about\n"), 0o600))
+
+	encrypted := runTest(t, "keygen", "-mnemonic-file", mnemonicFile, "-path", "m/0", "-password-file", passwordFile,
+		"-kdf", "pbkdf2")
+	plain := runTest(t, "keygen", "-mnemonic-file", mnemonicFile, "-path", "m/0")
+
+	// the passphrase changes the key
+	assert.NotEqual(t, plain,
+		runTest(t, "keygen", "-mnemonic-file", mnemonicFile, "-path", "m/0", "-passphrase-file", passwordFile))
+
+	key, err := keystore.Decrypt([]byte(encrypted), "password")
+	require.NoError(t, err)
+
+	raw, err := key.MarshalJSON()
+	require.NoError(t, err)
+
+	keystoreFile := filepath.Join(dir, "keystore.json")
+	require.NoError(t, os.WriteFile(keystoreFile, []byte(encrypted), 0o600))
+
+	plainFile := filepath.Join(dir, "plain")
+	require.NoError(t, os.WriteFile(plainFile, []byte(plain), 0o600))
+
+	assert.Equal(t, runTest(t, "pubkey", "-key-file", plainFile),
+		runTest(t, "pubkey", "-key-file", keystoreFile, "-password-file", passwordFile))
+	assert.Equal(t, plain, hex.EncodeToString(raw))
+
+	err = run([]string{"pubkey", "-key-file", keystoreFile}, new(bytes.Buffer))
+	assert.Error(t, err)
+}
+
+func TestCommands_Aggregate(t *testing.T) {
+	t.Parallel()
+
+	keys, err := core.CreateRandomBlsKeys(3)
+	require.NoError(t, err)
+
+	signatures := make([]string, len(keys))
+	pubkeys := make([]string, len(keys))
+
+	for i, key := range keys {
+		signature, err := key.Sign([]byte("abc"))
+		require.NoError(t, err)
+
+		raw, err := signature.Marshal()
+		require.NoError(t, err)
+
+		signatures[i] = hex.EncodeToString(raw)
+		pubkeys[i] = hex.EncodeToString(key.PublicKey().Marshal())
+	}
+
+	signature := runTest(t, append([]string{"aggregate-sigs"}, signatures...)...)
+	pubkey := runTest(t, append([]string{"aggregate-pubkeys", "-compressed"}, pubkeys...)...)
+
+	assert.Equal(t, "valid", runTest(t, "verify", "-pubkey", pubkey, "-signature", signature, "-message", "abc"))
+
+	err = run([]string{"aggregate-sigs"}, new(bytes.Buffer))
+	assert.Error(t, err)
+}
+
+func TestCommands_Pop(t *testing.T) {
+	t.Parallel()
+
+	key, err := core.GenerateBlsKey()
+	require.NoError(t, err)
+
+	raw, err := key.MarshalJSON()
+	require.NoError(t, err)
+
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(hex.EncodeToString(raw)), 0o600))
+
+	pubkey := runTest(t, "pubkey", "-key-file", keyFile)
+	proof := runTest(t, "pop", "-key-file", keyFile)
+
+	assert.Equal(t, "valid", runTest(t, "pop", "-pubkey", pubkey, "-proof", proof))
+
+	signature := runTest(t, "sign", "-key-file", keyFile, "-message", "abc")
+
+	err = run([]string{"pop", "-pubkey", pubkey, "-proof", signature}, new(bytes.Buffer))
+	assert.ErrorIs(t, err, errInvalidSignature)
+}
+
+func TestCommands_Encode(t *testing.T) {
+	t.Parallel()
+
+	key, err := core.GenerateBlsKey()
+	require.NoError(t, err)
+
+	signature, err := key.Sign([]byte("abc"))
+	require.NoError(t, err)
+
+	raw, err := signature.Marshal()
+	require.NoError(t, err)
+
+	evm, err := signature.ToEVM()
+	require.NoError(t, err)
+
+	signatureHex := hex.EncodeToString(raw)
+	pubkeyHex := hex.EncodeToString(key.PublicKey().Marshal())
+
+	assert.Equal(t, "0x"+hex.EncodeToString(evm), runTest(t, "encode", "-format", "evm", "-signature", signatureHex))
+	assert.Equal(t, "0x"+hex.EncodeToString(key.PublicKey().ToEVM()),
+		runTest(t, "encode", "-format", "evm", "-pubkey", pubkeyHex))
+	assert.Equal(t, pubkeyHex, runTest(t, "encode", "-format", "hex", "-pubkey", pubkeyHex))
+
+	var coords []string
+
+	require.NoError(t, json.Unmarshal([]byte(runTest(t, "encode", "-format", "json", "-pubkey", pubkeyHex)), &coords))
+	require.Len(t, coords, 4)
+
+	expected := key.PublicKey().ToBigInt()
+	for i, c := range coords {
+		assert.Equal(t, expected[i].String(), c)
+	}
+
+	err = run([]string{"encode", "-format", "xml", "-pubkey", pubkeyHex}, new(bytes.Buffer))
+	assert.Error(t, err)
+
+	err = run([]string{"encode", "-format", "evm"}, new(bytes.Buffer))
+	assert.Error(t, err)
+}
+
+func TestCommands_Unknown(t *testing.T) {
+	t.Parallel()
+
+	assert.Error(t, run(nil, new(bytes.Buffer)))
+	assert.Error(t, run([]string{"unknown"}, new(bytes.Buffer)))
+}
+
+func runTest(t *testing.T, args ...string) string {
+	t.Helper()
+
+	out := new(bytes.Buffer)
+	require.NoError(t, run(args, out))
+
+	return strings.TrimSpace(out.String())
+}
@@ -0,0 +1,66 @@
+// Command bnsnark1 generates keys, signs and verifies messages and encodes keys and signatures
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// errInvalidSignature is returned by verify, so the exit code tells whether the signature is valid
+var errInvalidSignature = errors.New("invalid signature")
+
+type command struct {
+	usage string
+	run   func(args []string, stdout io.Writer) error
+}
+
+var commands = map[string]command{
+	"keygen":            {"generate a private key, from a mnemonic if given", runKeygen},
+	"pubkey":            {"print the public key of a private key", runPubkey},
+	"sign":              {"sign a message", runSign},
+	"verify":            {"verify a signature of a message", runVerify},
+	"aggregate-sigs":    {"aggregate signatures", runAggregateSigs},
+	"aggregate-pubkeys": {"aggregate public keys", runAggregatePubkeys},
+	"pop":               {"generate or verify a proof of possession", runPop},
+	"encode":            {"encode a public key or a signature as evm, hex or json", runEncode},
+	"signer":            {"serve the keys of keystores over http with slashing protection", runSigner},
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage())
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q\n%s", args[0], usage())
+	}
+
+	return cmd.run(args[1:], stdout)
+}
+
+func usage() string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	res := "usage: bnsnark1 <command> [flags]\n\ncommands:\n"
+	for _, name := range names {
+		res += fmt.Sprintf("  %-18s %s\n", name, commands[name].usage)
+	}
+
+	return res + "\nrun bnsnark1 <command> -h for the flags of the command"
+}
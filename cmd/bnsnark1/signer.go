@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/0xPolygon/bnsnark1/signer"
+)
+
+const signerReadTimeout = 10 * time.Second
+
+func runSigner(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("signer", flag.ContinueOnError)
+	keystores := fs.String("keystores", "", "directory of the *.json keystores")
+	passwordFile := fs.String("password-file", "", "password of the keystores")
+	db := fs.String("db", "slashing.db", "slashing protection database")
+	addr := fs.String("addr", "127.0.0.1:9000", "address to listen on, the API has no authentication so it must be trusted")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *keystores == "" || *passwordFile == "" {
+		return errors.New("-keystores and -password-file must be given")
+	}
+
+	password, err := readPassword(*passwordFile)
+	if err != nil {
+		return err
+	}
+
+	keys, err := signer.LoadKeystores(*keystores, password)
+	if err != nil {
+		return err
+	}
+
+	slashingDB, err := signer.OpenSlashingDB(*db)
+	if err != nil {
+		return err
+	}
+
+	defer slashingDB.Close()
+
+	for _, key := range keys {
+		fmt.Fprintln(stdout, "loaded key", signer.KeyID(key.PublicKey()))
+	}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           signer.NewServer(keys, slashingDB),
+		ReadHeaderTimeout: signerReadTimeout,
+		ReadTimeout:       signerReadTimeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		_ = server.Shutdown(context.Background())
+	}()
+
+	fmt.Fprintln(stdout, "listening on", *addr)
+
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package core
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+)
+
+// hotAggregateHits is the number of uses after which the pairing coefficients of an aggregate are precomputed
+const hotAggregateHits = 2
+
+var errCacheSize = errors.New("cache size must be positive")
+
+// aggregateEntry is a cached aggregate public key of the signers of a bitmap, with their number and voting power.
+// Only hits, coef and precomputing change once the entry is cached, under the lock of the aggregator
+type aggregateEntry struct {
+	bitmap  string
+	point   G2
+	signers int
+	power   uint64
+	hits    int
+	// coef are the coefficients of PrecomputeG2, computed once the entry is hot
+	coef         []uint64
+	precomputing bool
+}
+
+// KeyAggregator aggregates the public keys of subsets of a validator set. It keeps the sum of all of the keys,
+// so an aggregate costs the smaller of the number of signers and the number of non-signers in G2 additions,
+// and caches the most recently used aggregates by bitmap. It is safe for concurrent use
+type KeyAggregator struct {
+	scheme *Scheme
+	set    *ValidatorSet
+	total  G2
+
+	mu        sync.Mutex
+	cacheSize int
+	entries   map[string]*list.Element
+	lru       *list.List
+}
+
+// NewKeyAggregator creates the aggregator of the validator set for the default scheme,
+// caching up to cacheSize aggregates
+func NewKeyAggregator(set *ValidatorSet, cacheSize int) (*KeyAggregator, error) {
+	return defaultScheme.NewKeyAggregator(set, cacheSize)
+}
+
+// NewKeyAggregator creates the aggregator of the validator set verifying certificates with the scheme,
+// caching up to cacheSize aggregates
+func (s *Scheme) NewKeyAggregator(set *ValidatorSet, cacheSize int) (*KeyAggregator, error) {
+	if cacheSize <= 0 {
+		return nil, errCacheSize
+	}
+
+	a := &KeyAggregator{
+		scheme:    s,
+		set:       set,
+		cacheSize: cacheSize,
+		entries:   make(map[string]*list.Element, cacheSize),
+		lru:       list.New(),
+	}
+
+	a.total.Clear()
+
+	for _, v := range set.validators {
+		G2Add(&a.total, &a.total, v.PublicKey.p)
+	}
+
+	return a, nil
+}
+
+// Aggregate returns the aggregated public key of the validators marked in the bitmap
+func (a *KeyAggregator) Aggregate(bitmap Bitmap) (*PublicKey, error) {
+	entry, err := a.entry(bitmap, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPublicKey(&entry.point), nil
+}
+
+// VerifyCertificate is Scheme.VerifyCertificate with the cached aggregate of the signers.
+// The pairing with the aggregate is precomputed once it has been used hotAggregateHits times
+func (a *KeyAggregator) VerifyCertificate(certificate *AggregatedCertificate, message []byte, quorum uint64) error {
+	// certificates without quorum are rejected before their aggregate is computed or cached
+	entry, err := a.entry(certificate.Bitmap, func(signers int, power uint64) error {
+		return checkQuorum(certificate, signers, power, quorum)
+	})
+	if err != nil {
+		return err
+	}
+
+	messagePoint, err := a.scheme.HashToG1(message)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	coef := entry.coef
+	a.mu.Unlock()
+
+	if coef == nil {
+		if !certificate.Sig.verifyPoint(&PublicKey{p: &entry.point}, messagePoint) {
+			return ErrInvalidCertificate
+		}
+
+		return nil
+	}
+
+	// e(sig, g2) * e(-H(m), aggregate) == 1
+	e := new(GT)
+
+	G1Neg(messagePoint, messagePoint)
+	PrecomputedMillerLoop2(e, certificate.Sig.p, GetCoef(), messagePoint, coef)
+	FinalExp(e, e)
+
+	if !e.IsOne() {
+		return ErrInvalidCertificate
+	}
+
+	return nil
+}
+
+// entry returns the cached aggregate of the bitmap, computing and caching it on a miss. The number
+// and the voting power of the signers are passed to check, if not nil, before the entry is used.
+// The pairing coefficients of a hot entry are precomputed outside of the lock
+func (a *KeyAggregator) entry(bitmap Bitmap, check func(signers int, power uint64) error) (*aggregateEntry, error) {
+	key := string(bitmap)
+
+	a.mu.Lock()
+
+	if elem, ok := a.entries[key]; ok {
+		entry, _ := elem.Value.(*aggregateEntry)
+
+		if check != nil {
+			if err := check(entry.signers, entry.power); err != nil {
+				a.mu.Unlock()
+
+				return nil, err
+			}
+		}
+
+		a.lru.MoveToFront(elem)
+		entry.hits++
+
+		precompute := entry.hits >= hotAggregateHits && entry.coef == nil && !entry.precomputing
+		entry.precomputing = entry.precomputing || precompute
+
+		a.mu.Unlock()
+
+		if precompute {
+			coef := PrecomputeG2(&entry.point)
+
+			a.mu.Lock()
+			entry.coef = coef
+			a.mu.Unlock()
+		}
+
+		return entry, nil
+	}
+
+	a.mu.Unlock()
+
+	// the bitmap is validated and scanned once, the aggregate is computed from the indices of the signers
+	indices, power, err := a.set.signers(bitmap)
+	if err != nil {
+		return nil, err
+	}
+
+	if check != nil {
+		if err := check(len(indices), power); err != nil {
+			return nil, err
+		}
+	}
+
+	entry := &aggregateEntry{bitmap: key, signers: len(indices), power: power, hits: 1}
+	a.aggregate(&entry.point, indices)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// another goroutine may have cached the same aggregate meanwhile
+	if elem, ok := a.entries[key]; ok {
+		a.lru.MoveToFront(elem)
+
+		cached, _ := elem.Value.(*aggregateEntry)
+		cached.hits++
+
+		return cached, nil
+	}
+
+	a.entries[key] = a.lru.PushFront(entry)
+
+	if a.lru.Len() > a.cacheSize {
+		oldest := a.lru.Back()
+		a.lru.Remove(oldest)
+
+		evicted, _ := oldest.Value.(*aggregateEntry)
+		delete(a.entries, evicted.bitmap)
+	}
+
+	return entry, nil
+}
+
+// aggregate adds the keys of the signers at the increasing indices, or subtracts the keys of the non-signers
+// from the total when there are fewer of them
+func (a *KeyAggregator) aggregate(out *G2, indices []int) {
+	validators := a.set.validators
+
+	if len(indices) <= len(validators)-len(indices) {
+		out.Clear()
+
+		for _, i := range indices {
+			G2Add(out, out, validators[i].PublicKey.p)
+		}
+
+		return
+	}
+
+	*out = a.total
+	next := 0
+
+	for i, v := range validators {
+		if next < len(indices) && indices[next] == i {
+			next++
+
+			continue
+		}
+
+		G2Sub(out, out, v.PublicKey.p)
+	}
+}
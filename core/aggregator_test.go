@@ -0,0 +1,270 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyAggregator_Aggregate(t *testing.T) {
+	t.Parallel()
+
+	const n = 20
+
+	keys, set := testValidatorSet(t, n)
+
+	aggregator, err := NewKeyAggregator(set, 4)
+	require.NoError(t, err)
+
+	// few signers are added, many are subtracted from the total
+	for _, signers := range [][]int{{}, {7}, {0, 5, 19}, {1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, allSigners(n)} {
+		bitmap := NewBitmap(n)
+		publicKeys := make([]*PublicKey, 0, len(signers))
+
+		for _, signer := range signers {
+			bitmap.Set(signer)
+			publicKeys = append(publicKeys, keys[signer].PublicKey())
+		}
+
+		for i := 0; i < 2; i++ {
+			aggregate, err := aggregator.Aggregate(bitmap)
+			require.NoError(t, err)
+			assert.True(t, aggregate.p.IsEqual(AggregatePublicKeys(publicKeys).p), "%d signers", len(signers))
+		}
+	}
+
+	// the least recently used aggregates are evicted
+	assert.Equal(t, 4, aggregator.lru.Len())
+	assert.Len(t, aggregator.entries, 4)
+
+	_, err = aggregator.Aggregate(Bitmap{0xff, 0xff, 0xff})
+	assert.ErrorIs(t, err, ErrInvalidBitmap)
+
+	_, err = NewKeyAggregator(set, 0)
+	assert.ErrorIs(t, err, errCacheSize)
+}
+
+func TestKeyAggregator_VerifyCertificate(t *testing.T) {
+	t.Parallel()
+
+	const n = 9
+
+	message := testGenRandomBytes(t, 32)
+	keys, set := testValidatorSet(t, n)
+
+	aggregator, err := NewKeyAggregator(set, 8)
+	require.NoError(t, err)
+
+	for _, signers := range [][]int{{0, 1, 2, 3}, {2, 3, 4, 5, 6, 7, 8}} {
+		certificate := testCertificate(t, keys, message, signers...)
+		forged := &AggregatedCertificate{Sig: testCertificate(t, keys, message, signers[1:]...).Sig, Bitmap: certificate.Bitmap}
+
+		// the aggregate is precomputed from the second use on
+		for i := 0; i < hotAggregateHits+1; i++ {
+			require.NoError(t, aggregator.VerifyCertificate(certificate, message, 10))
+			assert.ErrorIs(t, aggregator.VerifyCertificate(certificate, []byte("other"), 10), ErrInvalidCertificate)
+			assert.ErrorIs(t, aggregator.VerifyCertificate(forged, message, 10), ErrInvalidCertificate)
+		}
+
+		entry, err := aggregator.entry(certificate.Bitmap, nil)
+		require.NoError(t, err)
+		assert.NotNil(t, entry.coef)
+	}
+
+	// the aggregates of certificates without quorum are not cached
+	certificate := testCertificate(t, keys, message, 0)
+	assert.ErrorIs(t, aggregator.VerifyCertificate(certificate, message, 2), ErrQuorumNotReached)
+	assert.NotContains(t, aggregator.entries, string(certificate.Bitmap))
+
+	certificate.Bitmap = append(certificate.Bitmap, 0)
+	assert.ErrorIs(t, aggregator.VerifyCertificate(certificate, message, 1), ErrInvalidBitmap)
+}
+
+func TestKeyAggregator_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	const (
+		n          = 64
+		goroutines = 16
+		iterations = 8
+	)
+
+	message := testGenRandomBytes(t, 32)
+	keys, set := testValidatorSet(t, n)
+
+	// the goroutines start by missing the same bitmap together and inserting its aggregate concurrently,
+	// then the certificate verified by every goroutine in every iteration becomes hot and is precomputed
+	// while the other ones are verified
+	hot := testCertificate(t, keys, message, allSigners(n)...)
+	others := []*AggregatedCertificate{
+		testCertificate(t, keys, message, allSigners(n/2)...),
+		testCertificate(t, keys, message, allSigners(n-1)...),
+		testCertificate(t, keys, message, 1, 3, 5, 7),
+	}
+
+	aggregator, err := NewKeyAggregator(set, len(others)+1)
+	require.NoError(t, err)
+
+	var wg, missed sync.WaitGroup
+
+	errs := make(chan error, goroutines)
+
+	// the check of a miss runs before the aggregate is cached, so no goroutine caches it before all of them missed
+	missed.Add(goroutines)
+
+	barrier := func(int, uint64) error {
+		missed.Done()
+		missed.Wait()
+
+		return nil
+	}
+
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+
+		go func(g int) {
+			defer wg.Done()
+
+			if _, err := aggregator.entry(hot.Bitmap, barrier); err != nil {
+				errs <- err
+
+				return
+			}
+
+			for i := 0; i < iterations; i++ {
+				other := others[(g+i)%len(others)]
+
+				for _, certificate := range []*AggregatedCertificate{hot, other} {
+					if err := aggregator.VerifyCertificate(certificate, message, 1); err != nil {
+						errs <- err
+
+						return
+					}
+				}
+
+				if _, err := aggregator.Aggregate(other.Bitmap); err != nil {
+					errs <- err
+
+					return
+				}
+			}
+		}(g)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	// every bitmap was inserted once
+	assert.Equal(t, len(others)+1, aggregator.lru.Len())
+	assert.Len(t, aggregator.entries, len(others)+1)
+
+	elem, ok := aggregator.entries[string(hot.Bitmap)]
+	require.True(t, ok)
+
+	entry, _ := elem.Value.(*aggregateEntry)
+	assert.NotNil(t, entry.coef)
+	assert.Equal(t, goroutines*(iterations+1), entry.hits)
+}
+
+func TestKeyAggregator_Eviction(t *testing.T) {
+	t.Parallel()
+
+	const n = 10
+
+	_, set := testValidatorSet(t, n)
+
+	aggregator, err := NewKeyAggregator(set, 2)
+	require.NoError(t, err)
+
+	bitmaps := make([]Bitmap, 3)
+	for i := range bitmaps {
+		bitmaps[i] = NewBitmap(n)
+		bitmaps[i].Set(i)
+	}
+
+	aggregate := func(bitmap Bitmap) {
+		_, err := aggregator.Aggregate(bitmap)
+		require.NoError(t, err)
+	}
+
+	// the first bitmap is used again after the second, so the second is the least recently used one
+	aggregate(bitmaps[0])
+	aggregate(bitmaps[1])
+	aggregate(bitmaps[0])
+	aggregate(bitmaps[2])
+
+	assert.Contains(t, aggregator.entries, string(bitmaps[0]))
+	assert.NotContains(t, aggregator.entries, string(bitmaps[1]))
+	assert.Contains(t, aggregator.entries, string(bitmaps[2]))
+
+	front, _ := aggregator.lru.Front().Value.(*aggregateEntry)
+	back, _ := aggregator.lru.Back().Value.(*aggregateEntry)
+	assert.Equal(t, string(bitmaps[2]), front.bitmap)
+	assert.Equal(t, string(bitmaps[0]), back.bitmap)
+}
+
+func BenchmarkKeyAggregator(b *testing.B) {
+	const n = 100
+
+	publicKeys := make([]*PublicKey, n)
+	validators := make([]Validator, n)
+
+	for i := range validators {
+		key, err := GenerateBlsKey()
+		require.NoError(b, err)
+
+		publicKeys[i] = key.PublicKey()
+		validators[i] = Validator{PublicKey: publicKeys[i], VotingPower: 1}
+	}
+
+	set, err := NewValidatorSet(validators)
+	require.NoError(b, err)
+
+	// all but one of the validators sign
+	bitmap := NewBitmap(n)
+	for i := 1; i < n; i++ {
+		bitmap.Set(i)
+	}
+
+	b.Run("AggregatePublicKeys", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			AggregatePublicKeys(publicKeys[1:])
+		}
+	})
+
+	for _, cacheSize := range []int{1, 1024} {
+		aggregator, err := NewKeyAggregator(set, cacheSize)
+		require.NoError(b, err)
+
+		// a cache of one entry misses on every other bitmap
+		other := bytes.Repeat([]byte{0xff}, len(bitmap))
+		other[len(other)-1] = 0x0f
+
+		b.Run(fmt.Sprintf("KeyAggregator/cache=%d", cacheSize), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if i%2 == 0 {
+					_, _ = aggregator.Aggregate(bitmap)
+				} else {
+					_, _ = aggregator.Aggregate(other)
+				}
+			}
+		})
+	}
+}
+
+func allSigners(n int) []int {
+	signers := make([]int, n)
+	for i := range signers {
+		signers[i] = i
+	}
+
+	return signers
+}
@@ -0,0 +1,22 @@
+package core
+
+// The curve arithmetic is provided by one of two backends with the same API:
+// mcl.go wraps the mcl library through cgo and is used by default,
+// purego.go is a pure Go implementation used when cgo is disabled or the purego build tag is set
+
+const ZERO_HEADER = 1 << 6
+
+func isZeroFormat(buf []byte, n int) bool {
+	if len(buf) < n {
+		return false
+	}
+	if buf[0] != ZERO_HEADER {
+		return false
+	}
+	for i := 1; i < n; i++ {
+		if buf[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,164 @@
+package core
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// the vectors are produced by mcl, so both backends must reproduce them bit for bit
+func TestBackend_KnownAnswers(t *testing.T) {
+	t.Parallel()
+
+	message := []byte("abc")
+
+	scalar := new(Fr)
+	require.NoError(t, scalar.SetString(
+		"8167889516377210183437218098367301532465843271458328823541837623982183762222", 10))
+
+	key := NewPrivateKey(scalar)
+
+	marshaled, err := key.MarshalJSON()
+	require.NoError(t, err)
+	assert.Equal(t, "2e491d74bf5bd46513455accd9b3700d852051d8d745654b191ba45294dc0e12", hex.EncodeToString(marshaled))
+
+	publicKey := key.PublicKey()
+	assert.Equal(t,
+		"16c20834259bc8e52e9e5115d5ec8af7f56552560854ab37d004b3fca79a142812a21ba7c00daa534c33e2ab1f4672db37745cab132b7279444fe3fd2a482819"+
+			"8fd560ba182536c4e10c7eaaf94efca0ce36c9eba70ef78455d5b4a88390301245403fc2b88737d9032d6ddbc9db72e7526c73e9f1ca4841ef93247417284d24",
+		hex.EncodeToString(publicKey.Marshal()))
+	assert.Equal(t,
+		"16c20834259bc8e52e9e5115d5ec8af7f56552560854ab37d004b3fca79a142812a21ba7c00daa534c33e2ab1f4672db37745cab132b7279444fe3fd2a482899",
+		hex.EncodeToString(publicKey.p.Serialize()))
+
+	hashed, err := HashToG107(message)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"b67b7ea132fb174d094138b85888fc6dce3ca924866997fefa7f4f0981af0918037c11e7548d8c974665d35af4a10864d068e5321d4bbc2e87784468c0ecd211",
+		hex.EncodeToString(G1ToBytes(hashed)))
+
+	signature, err := key.Sign(message)
+	require.NoError(t, err)
+
+	marshaled, err = signature.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t,
+		"7ee28dc440d993aec302fff8383250e90441821ce063df61844278bb80441101f0fc57e91dd7e0a00907787156420609dc3225aae3c30b548406676ccfb93412",
+		hex.EncodeToString(marshaled))
+	assert.Equal(t, "7ee28dc440d993aec302fff8383250e90441821ce063df61844278bb80441101", hex.EncodeToString(signature.p.Serialize()))
+
+	assert.True(t, signature.Verify(publicKey, message))
+	assert.False(t, signature.Verify(publicKey, []byte("abd")))
+
+	g1 := new(G1)
+	require.NoError(t, g1.HashAndMapTo(message))
+	assert.Equal(t,
+		"1 1497d0f78492391f29965cae37d5df548b48a513de76b6f6d95abafcfcb22db9 83dce544fc07eace17d9815a66f7b068c40dfbe2058d27beaf3c77573da7e17",
+		g1.GetString(16))
+
+	g2 := new(G2)
+	require.NoError(t, g2.HashAndMapTo(message))
+	assert.Equal(t,
+		"1 1474c33b68d8ccb8e7df4a4af76fa36c626000d80091e0fb9a6e29a362b8b007 2cb3ac5b7187a56492582db7e4fe89aa8c1acf1274f42eb7e9a1562124dccdb0"+
+			" 13e72c002114650a69b68c1d6df34da7e9f7751b11511b8debc78e450b75fb72 3bcb294bd32411b7bffa47e33c778f58d054ad81dae7d02dffd33941bd23434",
+		g2.GetString(16))
+
+	g2, err = HashToG2(message, []byte(DefaultG2DST))
+	require.NoError(t, err)
+	assert.Equal(t,
+		"1 84f90f678e8120b5a8537cd8307ecabc8624906c0486d15128cc1ee2288210f 24f6fc266e6bdc736ed7dd06a0ec0c8e4d7d4ffbff926a9f5e7aca97f51e5db"+
+			" 207e19f19504191d9c6e40139d334036a03fea843f9265bb0ade080c847d6848 217ca44ceda6560885991fde94b02de03d6854ec00f85b954e6d3e5e6f96201e",
+		g2.GetString(16))
+
+	fr := new(Fr)
+	require.True(t, fr.SetHashOf(message))
+	assert.Equal(t, "2d1500f261ff10b49c7a1796a36103b02322ae5dde404141eacf018fbf1678ba", fr.GetString(16))
+
+	fp := new(Fp)
+	require.NoError(t, fp.SetLittleEndianMod(bytesOf(0xff, 64)))
+	assert.Equal(t, "6d89f71cab8351f47ab1eff0a417ff6b5e71911d44501fbf32cfc5b538afa88", fp.GetString(16))
+
+	require.NoError(t, fp.SetBigEndianMod(append(bytesOf(0xff, 32), 0x01)))
+	assert.Equal(t, "d791464ef86e357276f48b709e2a0fcad825aed9626b0fffbdb0f2afaec657b", fp.GetString(16))
+
+	// both backends reject inputs larger than twice the field size
+	assert.Error(t, fp.SetLittleEndianMod(bytesOf(0xff, 65)))
+	assert.Error(t, fp.SetBigEndianMod(bytesOf(0xff, 65)))
+	assert.Error(t, fr.SetLittleEndianMod(bytesOf(0xff, 65)))
+	assert.Error(t, fr.SetBigEndianMod(bytesOf(0xff, 65)))
+
+	fp.SetInt64(49)
+	require.True(t, FpSquareRoot(fp, fp))
+	assert.Equal(t, "7", fp.GetString(10))
+
+	fr.SetInt64(49)
+	require.True(t, FrSquareRoot(fr, fr))
+	assert.Equal(t,
+		"21888242871839275222246405745257275088548364400416034343698204186575808495610", fr.GetString(10))
+
+	p := new(G1)
+	require.NoError(t, p.SetString("1 1 2", 16))
+	assert.Equal(t, "01"+hex.EncodeToString(make([]byte, 31)), hex.EncodeToString(p.Serialize()))
+
+	G1Neg(p, p)
+	assert.Equal(t, "01"+hex.EncodeToString(make([]byte, 30))+"80", hex.EncodeToString(p.Serialize()))
+
+	var gt GT
+
+	G1Neg(p, p)
+	Pairing(&gt, p, GetG2Generator())
+	assert.Equal(t,
+		"262b253feda94cfe0da01bde280a3ed6f87e5feb898578b55e1f63739d870e95 2e02d2cc795a2000a1b1f823879abbd397c4dea0918ed66b49d34b48efb8a4a"+
+			" 13a9f2d6e29b128da5b1ad44b31977935fd2957387ecb1fc4e135402fdbd1de0 40ba9fa500f1a5c4b31984a74e68659c4b420bd699ce630b130b08a6ea1162b"+
+			" afc2f3fd870678fbe359d7f9873f052478f590b211ce30bf5e3eeaef89eafdb 1c54a530398c9064bdc662d929e645cadda9a712cc5a8243f9cddbd2d98dd1f0"+
+			" 95c0fbf5d5a1ac023794a0d856f92591ba990ecfd4b7aef5c0d58c5dc2429fe 14d3d6ca72d8a950a31dc10f7b4053c9e9ad9ebb590cb4a60f8215d4b99f2b4a"+
+			" 1dc0e7bbc3d70e6689dc206b4b91c85759dc1a23043c585fdfaf545838ca7429 b53320e5a6488cb98a855ffc837d2a75ab90d61ac16cc1b7ab2cd3ed5e22b97"+
+			" 13a8afd3085dae4c6c91476ef36cd1d318ce07bac42a9c0f9bd7fddaf5ebd723 f97b5221474526b601f3730a3afa965ceee1b343940c383e5314859e762c97",
+		gt.GetString(16))
+}
+
+func TestBackend_PrecomputedMillerLoop2(t *testing.T) {
+	t.Parallel()
+
+	var a, b Fr
+
+	a.SetInt64(3)
+	b.SetInt64(5)
+
+	p1, p2 := new(G1), new(G1)
+	G1Mul(p1, GetG1Generator(), &a)
+	G1Mul(p2, GetG1Generator(), &b)
+
+	q2 := new(G2)
+	G2Mul(q2, GetG2Generator(), &b)
+
+	// e(p1, g2) * e(p2, q2) with distinct arguments, which were once ignored in favor of the first pair
+	expected, e1, e2 := new(GT), new(GT), new(GT)
+	MillerLoop(e1, p1, GetG2Generator())
+	MillerLoop(e2, p2, q2)
+	GTMul(expected, e1, e2)
+	FinalExp(expected, expected)
+
+	actual := new(GT)
+	PrecomputedMillerLoop2(actual, p1, GetCoef(), p2, PrecomputeG2(q2))
+	FinalExp(actual, actual)
+
+	assert.True(t, actual.IsEqual(expected))
+
+	// e(p1, g2)^2 is what the first pair alone gives
+	MillerLoop(e1, p1, GetG2Generator())
+	GTMul(e1, e1, e1)
+	FinalExp(e1, e1)
+	assert.False(t, actual.IsEqual(e1))
+}
+
+func bytesOf(b byte, n int) []byte {
+	buf := make([]byte, n)
+	for i := range buf {
+		buf[i] = b
+	}
+
+	return buf
+}
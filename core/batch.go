@@ -0,0 +1,157 @@
+package core
+
+import (
+	"errors"
+)
+
+var (
+	errBatchEntryEmpty = errors.New("public key and signature must not be empty")
+	errBatchScalar     = errors.New("error generating random batch scalar")
+)
+
+// batchScalarSize is the size in bytes of random scalars used to combine batch entries
+const batchScalarSize = 16
+
+type batchEntry struct {
+	publicKey    *PublicKey
+	signature    *Signature
+	messagePoint *G1
+	scalar       Fr
+}
+
+// BatchVerifier verifies many independent (public key, message, signature) triples at once.
+// Entries are combined with random 128-bit scalars, so the batch is checked with a single
+// multi Miller loop and a single final exponentiation. The zero value hashes the messages with the default scheme
+type BatchVerifier struct {
+	scheme  *Scheme
+	entries []batchEntry
+}
+
+// NewBatchVerifier creates an empty BatchVerifier for the default scheme
+func NewBatchVerifier() *BatchVerifier {
+	return defaultScheme.NewBatchVerifier()
+}
+
+// Add hashes the message, draws the random scalar of the entry and adds the triple to the batch
+func (b *BatchVerifier) Add(publicKey *PublicKey, message []byte, signature *Signature) error {
+	if publicKey == nil || publicKey.p == nil || signature == nil || signature.p == nil {
+		return errBatchEntryEmpty
+	}
+
+	scheme := b.scheme
+	if scheme == nil {
+		scheme = defaultScheme
+	}
+
+	messagePoint, err := scheme.HashToG1(message)
+	if err != nil {
+		return err
+	}
+
+	entry := batchEntry{
+		publicKey:    publicKey,
+		signature:    signature,
+		messagePoint: messagePoint,
+	}
+
+	if err := randomBatchScalar(&entry.scalar); err != nil {
+		return err
+	}
+
+	b.entries = append(b.entries, entry)
+
+	return nil
+}
+
+// Len returns the number of entries in the batch
+func (b *BatchVerifier) Len() int {
+	return len(b.entries)
+}
+
+// Verify checks all of the entries in the batch. If the batch is invalid,
+// it is bisected and the indices of the invalid entries (in order of Add calls) are returned
+func (b *BatchVerifier) Verify() (bool, []int) {
+	if len(b.entries) == 0 {
+		return true, nil
+	}
+
+	indices := make([]int, len(b.entries))
+	for i := range indices {
+		indices[i] = i
+	}
+
+	if b.verifySubset(indices) {
+		return true, nil
+	}
+
+	return false, b.bisect(indices)
+}
+
+// bisect returns invalid entries of the given subset which is known to be invalid
+func (b *BatchVerifier) bisect(indices []int) []int {
+	if len(indices) == 1 {
+		return indices
+	}
+
+	mid := len(indices) / 2
+	left, right := indices[:mid], indices[mid:]
+
+	var invalid []int
+
+	leftValid := b.verifySubset(left)
+	if !leftValid {
+		invalid = append(invalid, b.bisect(left)...)
+	}
+
+	// if the left half is valid, the right half must contain an invalid entry
+	if !leftValid && b.verifySubset(right) {
+		return invalid
+	}
+
+	return append(invalid, b.bisect(right)...)
+}
+
+// verifySubset checks e(sum(r_i * sig_i), g2) * prod(e(-r_i * H(m_i), pk_i)) == 1
+func (b *BatchVerifier) verifySubset(indices []int) bool {
+	g1s := make([]G1, len(indices)+1)
+	g2s := make([]G2, len(indices)+1)
+	g2s[0] = *ellipticCurveG2
+
+	tmp := new(G1)
+
+	for i, idx := range indices {
+		entry := &b.entries[idx]
+
+		G1Mul(tmp, entry.signature.p, &entry.scalar)
+		G1Add(&g1s[0], &g1s[0], tmp)
+
+		G1Mul(&g1s[i+1], entry.messagePoint, &entry.scalar)
+		G1Neg(&g1s[i+1], &g1s[i+1])
+
+		g2s[i+1] = *entry.publicKey.p
+	}
+
+	e := new(GT)
+
+	MillerLoopVec(e, g1s, g2s)
+	FinalExp(e, e)
+
+	return e.IsOne()
+}
+
+// randomBatchScalar sets x to a random non-zero 128-bit scalar
+func randomBatchScalar(x *Fr) error {
+	for {
+		if !x.SetByCSPRNG() {
+			return errBatchScalar
+		}
+
+		if err := x.SetLittleEndian(x.Serialize()[:batchScalarSize]); err != nil {
+			return err
+		}
+
+		if !x.IsZero() {
+			return nil
+		}
+	}
+}
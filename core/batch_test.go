@@ -0,0 +1,85 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchVerifier_Verify(t *testing.T) {
+	t.Parallel()
+
+	const batchSize = 16
+
+	blsKeys, err := CreateRandomBlsKeys(batchSize)
+	require.NoError(t, err)
+
+	messages := make([][]byte, batchSize)
+	signatures := make([]*Signature, batchSize)
+
+	for i, key := range blsKeys {
+		messages[i] = testGenRandomBytes(t, 32)
+
+		signatures[i], err = key.Sign(messages[i])
+		require.NoError(t, err)
+	}
+
+	valid := NewBatchVerifier()
+
+	for i, key := range blsKeys {
+		require.NoError(t, valid.Add(key.PublicKey(), messages[i], signatures[i]))
+	}
+
+	ok, invalid := valid.Verify()
+	assert.True(t, ok)
+	assert.Empty(t, invalid)
+	assert.Equal(t, batchSize, valid.Len())
+
+	invalidIndices := []int{0, 5, 6, 15}
+	batch := NewBatchVerifier()
+
+	for i, key := range blsKeys {
+		message := messages[i]
+
+		for _, idx := range invalidIndices {
+			if idx == i {
+				message = messages[(i+1)%batchSize]
+			}
+		}
+
+		require.NoError(t, batch.Add(key.PublicKey(), message, signatures[i]))
+	}
+
+	ok, invalid = batch.Verify()
+	assert.False(t, ok)
+	assert.Equal(t, invalidIndices, invalid)
+
+	ok, invalid = NewBatchVerifier().Verify()
+	assert.True(t, ok)
+	assert.Empty(t, invalid)
+
+	assert.Error(t, batch.Add(&PublicKey{}, messages[0], signatures[0]))
+}
+
+func TestBatchVerifier_ZeroValue(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	message := []byte("message")
+
+	signature, err := key.Sign(message)
+	require.NoError(t, err)
+
+	// the zero value uses the default scheme
+	var batch BatchVerifier
+
+	require.NoError(t, batch.Add(key.PublicKey(), message, signature))
+	require.NoError(t, batch.Add(key.PublicKey(), []byte("other"), signature))
+
+	ok, invalid := batch.Verify()
+	assert.False(t, ok)
+	assert.Equal(t, []int{1}, invalid)
+}
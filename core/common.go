@@ -8,6 +8,12 @@ import (
 var (
 	domain, _ = hex.DecodeString("508e30424791cb9a71683381558c3da1979b6fa423b2d6db1396b1d94d7c4a78")
 
+	ellipticCurveG1 = &G1{
+		X: newFp(0xd35d438dc58f0d9d, 0x0a78eb28f5c70b3d, 0x666ea36f7879462c, 0x0e0a77c19a07df2f),
+		Y: newFp(0xa6ba871b8b1e1b3a, 0x14f1d651eb8e167b, 0xccdd46def0f28c58, 0x1c14ef83340fbe5e),
+		Z: newFp(0xd35d438dc58f0d9d, 0x0a78eb28f5c70b3d, 0x666ea36f7879462c, 0x0e0a77c19a07df2f),
+	}
+
 	ellipticCurveG2 = &G2{
 		X: Fp2{
 			[2]Fp{
@@ -52,6 +58,8 @@ func init() {
 	HashToG1 = HashToG107
 }
 
+// SetDomain sets the domain of the default scheme. It is not safe to call concurrently with signing
+// or verification, use NewScheme for messages signed under different domains
 func SetDomain(_domain []byte) {
 	domain = _domain
 }
@@ -61,6 +69,20 @@ func GetDomain() []byte {
 	return domain
 }
 
+// GetG1Generator returns a copy of the G1 generator used for public keys in G1
+func GetG1Generator() *G1 {
+	g1 := *ellipticCurveG1
+
+	return &g1
+}
+
+// GetG2Generator returns a copy of the G2 generator used for public keys
+func GetG2Generator() *G2 {
+	g2 := *ellipticCurveG2
+
+	return &g2
+}
+
 func GetCoef() []uint64 {
 	return qCoef
 }
@@ -0,0 +1,307 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// The EVM precompiles of EIP-196 and EIP-197 encode a coordinate as a big-endian uint256 and
+// an Fp2 element a + bi as (b, a), i.e. imaginary part first. The identity is encoded as zeros
+
+const evmWordSize = 32
+
+var (
+	errInvalidEVMCoordinate = errors.New("coordinate must be a uint256 smaller than the field modulus")
+	errPairingInputLength   = errors.New("pairing input must contain the same number of G1 and G2 points")
+)
+
+// G1ToEVM encodes the point as x || y in big-endian
+func G1ToEVM(p *G1) []byte {
+	res := make([]byte, 2*evmWordSize)
+	if p.IsZero() {
+		return res
+	}
+
+	g1 := new(G1)
+	G1Normalize(g1, p)
+
+	for i, x := range []*Fp{&g1.X, &g1.Y} {
+		copy(res[i*evmWordSize:], fpToEVM(x))
+	}
+
+	return res
+}
+
+// G1FromEVM decodes the point encoded by G1ToEVM. The point must be on the curve
+func G1FromEVM(raw []byte) (*G1, error) {
+	if len(raw) != 2*evmWordSize {
+		return nil, fmt.Errorf("expect length %d but got %d", 2*evmWordSize, len(raw))
+	}
+
+	g1 := new(G1)
+	if isZeroBytes(raw) {
+		return g1, nil
+	}
+
+	for i, x := range []*Fp{&g1.X, &g1.Y} {
+		if err := fpFromEVM(x, raw[i*evmWordSize:(i+1)*evmWordSize]); err != nil {
+			return nil, err
+		}
+	}
+
+	g1.Z.SetInt64(1)
+
+	if !g1.IsValid() {
+		return nil, ErrPointNotOnCurve
+	}
+
+	return g1, nil
+}
+
+// G2ToEVM encodes the point as x.D[1] || x.D[0] || y.D[1] || y.D[0] in big-endian
+func G2ToEVM(p *G2) []byte {
+	res := make([]byte, 4*evmWordSize)
+	if p.IsZero() {
+		return res
+	}
+
+	g2 := new(G2)
+	G2Normalize(g2, p)
+
+	for i, x := range []*Fp{&g2.X.D[1], &g2.X.D[0], &g2.Y.D[1], &g2.Y.D[0]} {
+		copy(res[i*evmWordSize:], fpToEVM(x))
+	}
+
+	return res
+}
+
+// G2FromEVM decodes the point encoded by G2ToEVM. The point must be on the twist curve
+func G2FromEVM(raw []byte) (*G2, error) {
+	if len(raw) != 4*evmWordSize {
+		return nil, fmt.Errorf("expect length %d but got %d", 4*evmWordSize, len(raw))
+	}
+
+	g2 := new(G2)
+	if isZeroBytes(raw) {
+		return g2, nil
+	}
+
+	for i, x := range []*Fp{&g2.X.D[1], &g2.X.D[0], &g2.Y.D[1], &g2.Y.D[0]} {
+		if err := fpFromEVM(x, raw[i*evmWordSize:(i+1)*evmWordSize]); err != nil {
+			return nil, err
+		}
+	}
+
+	g2.Z.D[0].SetInt64(1)
+
+	if !g2.IsValid() {
+		return nil, ErrPointNotOnCurve
+	}
+
+	return g2, nil
+}
+
+// G1ToBigInt returns the affine coordinates of the point as (x, y), the identity is (0, 0)
+func G1ToBigInt(p *G1) [2]*big.Int {
+	raw := G1ToEVM(p)
+
+	return [2]*big.Int{
+		new(big.Int).SetBytes(raw[:evmWordSize]),
+		new(big.Int).SetBytes(raw[evmWordSize:]),
+	}
+}
+
+// G1FromBigInt is the inverse of G1ToBigInt
+func G1FromBigInt(coords [2]*big.Int) (*G1, error) {
+	raw, err := bigIntsToEVM(coords[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return G1FromEVM(raw)
+}
+
+// G2ToBigInt returns the affine coordinates of the point in the EVM order (x.D[1], x.D[0], y.D[1], y.D[0]),
+// which is also how Solidity verifiers usually store a G2 point in uint256[4]
+func G2ToBigInt(p *G2) [4]*big.Int {
+	raw := G2ToEVM(p)
+
+	var res [4]*big.Int
+	for i := range res {
+		res[i] = new(big.Int).SetBytes(raw[i*evmWordSize : (i+1)*evmWordSize])
+	}
+
+	return res
+}
+
+// G2FromBigInt is the inverse of G2ToBigInt
+func G2FromBigInt(coords [4]*big.Int) (*G2, error) {
+	raw, err := bigIntsToEVM(coords[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return G2FromEVM(raw)
+}
+
+// ToEVM encodes the signature for the EVM precompiles
+func (s *Signature) ToEVM() ([]byte, error) {
+	if s.p == nil {
+		return nil, errors.New("cannot marshal empty signature")
+	}
+
+	return G1ToEVM(s.p), nil
+}
+
+// ToBigInt returns the coordinates of the signature as they are passed to a Solidity verifier
+func (s *Signature) ToBigInt() ([2]*big.Int, error) {
+	if s.p == nil {
+		return [2]*big.Int{}, errors.New("cannot marshal empty signature")
+	}
+
+	return G1ToBigInt(s.p), nil
+}
+
+// SignatureFromEVM reads the signature encoded for the EVM precompiles.
+// The point must be on the curve, in the prime-order subgroup and must not be the identity
+func SignatureFromEVM(raw []byte) (*Signature, error) {
+	g1, err := G1FromEVM(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateG1(g1); err != nil {
+		return nil, err
+	}
+
+	return &Signature{p: g1}, nil
+}
+
+// ToEVM encodes the public key for the EVM precompiles
+func (p *PublicKey) ToEVM() []byte {
+	if p.p == nil {
+		return nil
+	}
+
+	return G2ToEVM(p.p)
+}
+
+// ToBigInt returns the coordinates of the public key as they are passed to a Solidity verifier
+func (p *PublicKey) ToBigInt() [4]*big.Int {
+	if p.p == nil {
+		return [4]*big.Int{}
+	}
+
+	return G2ToBigInt(p.p)
+}
+
+// PublicKeyFromEVM reads the public key encoded for the EVM precompiles.
+// The point must be on the curve, in the prime-order subgroup and must not be the identity
+func PublicKeyFromEVM(raw []byte) (*PublicKey, error) {
+	g2, err := G2FromEVM(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateG2(g2); err != nil {
+		return nil, err
+	}
+
+	return &PublicKey{p: g2}, nil
+}
+
+// MarshalMessageToEVM hashes the message to G1 and encodes the point for the EVM precompiles
+func MarshalMessageToEVM(message []byte) ([]byte, error) {
+	g1, err := HashToG1(message)
+	if err != nil {
+		return nil, err
+	}
+
+	return G1ToEVM(g1), nil
+}
+
+// MarshalMessageToBigInt hashes the message to G1 and returns the coordinates of the point
+func MarshalMessageToBigInt(message []byte) ([2]*big.Int, error) {
+	g1, err := HashToG1(message)
+	if err != nil {
+		return [2]*big.Int{}, err
+	}
+
+	return G1ToBigInt(g1), nil
+}
+
+// EncodePairingInput builds the input of the ecPairing precompile, which checks that
+// the product of e(g1s[i], g2s[i]) is one
+func EncodePairingInput(g1s []*G1, g2s []*G2) ([]byte, error) {
+	if len(g1s) != len(g2s) {
+		return nil, errPairingInputLength
+	}
+
+	res := make([]byte, 0, len(g1s)*6*evmWordSize)
+
+	for i := range g1s {
+		res = append(res, G1ToEVM(g1s[i])...)
+		res = append(res, G2ToEVM(g2s[i])...)
+	}
+
+	return res, nil
+}
+
+// PairingCalldata builds the ecPairing input which succeeds if the signature of the message
+// is valid for the public key, i.e. e(signature, -G2) * e(H(message), publicKey) == 1.
+// Aggregated signatures over the same message are checked against the aggregated public key
+func (s *Signature) PairingCalldata(publicKey *PublicKey, message []byte) ([]byte, error) {
+	if s.p == nil || publicKey.p == nil {
+		return nil, errors.New("cannot encode empty signature or public key")
+	}
+
+	messagePoint, err := HashToG1(message)
+	if err != nil {
+		return nil, err
+	}
+
+	negG2 := new(G2)
+	G2Neg(negG2, ellipticCurveG2)
+
+	return EncodePairingInput([]*G1{s.p, messagePoint}, []*G2{negG2, publicKey.p})
+}
+
+func fpToEVM(x *Fp) []byte {
+	raw := x.Serialize()
+	res := make([]byte, len(raw))
+
+	for i, b := range raw {
+		res[len(raw)-1-i] = b
+	}
+
+	return padLeftOrTrim(res, evmWordSize)
+}
+
+func fpFromEVM(x *Fp, raw []byte) error {
+	le := make([]byte, len(raw))
+
+	for i, b := range raw {
+		le[len(raw)-1-i] = b
+	}
+
+	if err := x.Deserialize(le); err != nil {
+		return errInvalidEVMCoordinate
+	}
+
+	return nil
+}
+
+func bigIntsToEVM(coords []*big.Int) ([]byte, error) {
+	res := make([]byte, len(coords)*evmWordSize)
+
+	for i, c := range coords {
+		if c == nil || c.Sign() < 0 || c.BitLen() > 8*evmWordSize {
+			return nil, errInvalidEVMCoordinate
+		}
+
+		c.FillBytes(res[i*evmWordSize : (i+1)*evmWordSize])
+	}
+
+	return res, nil
+}
@@ -0,0 +1,178 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEVM_Generators(t *testing.T) {
+	t.Parallel()
+
+	g1 := new(G1)
+	require.NoError(t, g1.SetString("1 1 2", 10))
+
+	coords := G1ToBigInt(g1)
+	assert.Equal(t, int64(1), coords[0].Int64())
+	assert.Equal(t, int64(2), coords[1].Int64())
+
+	// the G2 generator as specified in EIP-197
+	expected := [4]string{
+		"11559732032986387107991004021392285783925812861821192530917403151452391805634",
+		"10857046999023057135944570762232829481370756359578518086990519993285655852781",
+		"4082367875863433681332203403145435568316851327593401208105741076214120093531",
+		"8495653923123431417604973247489272438418190587263600148770280649306958101930",
+	}
+
+	for i, c := range G2ToBigInt(GetG2Generator()) {
+		assert.Equal(t, expected[i], c.String())
+	}
+
+	assert.Equal(t, make([]byte, 64), G1ToEVM(new(G1)))
+	assert.Equal(t, make([]byte, 128), G2ToEVM(new(G2)))
+}
+
+func TestEVM_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	signature, err := key.Sign([]byte("evm"))
+	require.NoError(t, err)
+
+	raw, err := signature.ToEVM()
+	require.NoError(t, err)
+
+	decodedSignature, err := SignatureFromEVM(raw)
+	require.NoError(t, err)
+	assert.True(t, decodedSignature.p.IsEqual(signature.p))
+
+	coords, err := signature.ToBigInt()
+	require.NoError(t, err)
+
+	g1, err := G1FromBigInt(coords)
+	require.NoError(t, err)
+	assert.True(t, g1.IsEqual(signature.p))
+
+	decodedKey, err := PublicKeyFromEVM(key.PublicKey().ToEVM())
+	require.NoError(t, err)
+	assert.Equal(t, key.PublicKey().Marshal(), decodedKey.Marshal())
+
+	g2, err := G2FromBigInt(key.PublicKey().ToBigInt())
+	require.NoError(t, err)
+	assert.True(t, g2.IsEqual(key.PublicKey().p))
+
+	messagePoint, err := HashToG1([]byte("evm"))
+	require.NoError(t, err)
+
+	raw, err = MarshalMessageToEVM([]byte("evm"))
+	require.NoError(t, err)
+
+	g1, err = G1FromEVM(raw)
+	require.NoError(t, err)
+	assert.True(t, g1.IsEqual(messagePoint))
+
+	coords, err = MarshalMessageToBigInt([]byte("evm"))
+	require.NoError(t, err)
+	assert.Equal(t, G1ToBigInt(messagePoint), coords)
+}
+
+func TestEVM_FromEVMInvalid(t *testing.T) {
+	t.Parallel()
+
+	raw := G1ToEVM(ellipticCurveG1Test(t))
+	raw[63]++
+
+	_, err := G1FromEVM(raw)
+	assert.ErrorIs(t, err, ErrPointNotOnCurve)
+
+	_, err = G1FromBigInt([2]*big.Int{new(big.Int).Set(fieldOrderTest()), big.NewInt(2)})
+	assert.ErrorIs(t, err, errInvalidEVMCoordinate)
+
+	_, err = G1FromBigInt([2]*big.Int{big.NewInt(-1), big.NewInt(2)})
+	assert.ErrorIs(t, err, errInvalidEVMCoordinate)
+
+	_, err = SignatureFromEVM(make([]byte, 64))
+	assert.ErrorIs(t, err, ErrIdentityPoint)
+
+	_, err = PublicKeyFromEVM(G2ToEVM(testG2PointOutsideSubgroup(t)))
+	assert.ErrorIs(t, err, ErrNotInSubgroup)
+
+	_, err = G2FromEVM(make([]byte, 64))
+	assert.Error(t, err)
+}
+
+func TestEVM_PairingCalldata(t *testing.T) {
+	t.Parallel()
+
+	message := []byte("pairing")
+
+	keys, err := CreateRandomBlsKeys(3)
+	require.NoError(t, err)
+
+	signatures := make([]*Signature, len(keys))
+
+	for i, key := range keys {
+		signatures[i], err = key.Sign(message)
+		require.NoError(t, err)
+	}
+
+	signature := AggregateSignatures(signatures)
+	publicKey := AggregatePublicKeys(CollectPublicKeys(keys))
+
+	calldata, err := signature.PairingCalldata(publicKey, message)
+	require.NoError(t, err)
+	require.Len(t, calldata, 2*192)
+	assert.True(t, testEVMPairing(t, calldata))
+
+	calldata, err = signature.PairingCalldata(publicKey, []byte("other"))
+	require.NoError(t, err)
+	assert.False(t, testEVMPairing(t, calldata))
+
+	_, err = EncodePairingInput([]*G1{new(G1)}, nil)
+	assert.ErrorIs(t, err, errPairingInputLength)
+}
+
+// testEVMPairing runs the check of the ecPairing precompile on its input
+func testEVMPairing(t *testing.T, input []byte) bool {
+	t.Helper()
+
+	require.Zero(t, len(input)%192)
+
+	n := len(input) / 192
+	g1s, g2s := make([]G1, n), make([]G2, n)
+
+	for i := 0; i < n; i++ {
+		g1, err := G1FromEVM(input[i*192 : i*192+64])
+		require.NoError(t, err)
+
+		g2, err := G2FromEVM(input[i*192+64 : (i+1)*192])
+		require.NoError(t, err)
+
+		g1s[i], g2s[i] = *g1, *g2
+	}
+
+	e := new(GT)
+	MillerLoopVec(e, g1s, g2s)
+	FinalExp(e, e)
+
+	return e.IsOne()
+}
+
+func ellipticCurveG1Test(t *testing.T) *G1 {
+	t.Helper()
+
+	g1 := new(G1)
+	require.NoError(t, g1.SetString("1 1 2", 10))
+
+	return g1
+}
+
+func fieldOrderTest() *big.Int {
+	p, _ := new(big.Int).SetString(GetFieldOrder(), 10)
+
+	return p
+}
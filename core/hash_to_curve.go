@@ -0,0 +1,101 @@
+package core
+
+// SVDWCiphersuiteID identifies the scheme hashing messages to G1 by HashToG1SVDW, public keys are in G2
+const SVDWCiphersuiteID = "BLS_SIG_BN254G1_XMD:SHA-256_SVDW_RO_NUL_"
+
+// The constants of the Shallue-van de Woestijne map of RFC 9380 for y^2 = x^3 + 3 with Z = 1,
+// in Montgomery form
+var (
+	// svdwC1 = g(Z) = 4
+	svdwC1 = newFp(0x115482203dbf392d, 0x926242126eaa626a, 0xe16a48076063c052, 0x07c5909386eddc93)
+	// svdwC2 = -Z / 2
+	svdwC2 = newFp(0xb461a4448976f7d5, 0xc6843fb439555fa7, 0x28f0d12384840918, 0x112ceb58a394e07d)
+	// svdwC3 = sqrt(-g(Z) * 3Z^2) = sqrt(-12) with sgn0(svdwC3) = 0
+	svdwC3 = newFp(0x7c8487078735ab72, 0x51da7e0048bfb8d4, 0x945cfd183cbd7bf4, 0x0b70b1ec48ae62c6)
+	// svdwC4 = -4g(Z) / 3Z^2 = -16 / 3
+	svdwC4 = newFp(0xa79a2bdca0800831, 0x19fd7617e49815a1, 0xbb8d0c885550c7b1, 0x05c4aeb6ec7e0f48)
+)
+
+// HashToG1SVDW converts message to G1 point as BN254G1_XMD:SHA-256_SVDW_RO_ of RFC 9380
+// https://www.rfc-editor.org/rfc/rfc9380.html. Unlike HashToG107 it follows the final specification,
+// so the points are not compatible with signatures made by HashToG107
+func HashToG1SVDW(message, dst []byte) (*G1, error) {
+	hashRes, err := hashToFpXMDSHA256(message, dst, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	p0, p1 := new(G1), new(G1)
+
+	MapToG1SVDW(p0, hashRes[0])
+	MapToG1SVDW(p1, hashRes[1])
+
+	G1Add(p0, p0, p1)
+	G1Normalize(p0, p0)
+
+	return p0, nil
+}
+
+// MapToG1SVDW maps the field element to G1 with the Shallue-van de Woestijne map of RFC 9380, section 6.6.1.
+// The cofactor of G1 is one, so the point is in G1. The map is not constant time
+func MapToG1SVDW(out *G1, u *Fp) {
+	var one, tv1, tv2, tv3, tv4, x, y Fp
+
+	one.SetInt64(1)
+
+	// tv1 = u^2 c1, tv2 = 1 + tv1, tv1 = 1 - tv1, tv3 = inv0(tv1 tv2)
+	FpSqr(&tv1, u)
+	FpMul(&tv1, &tv1, &svdwC1)
+	FpAdd(&tv2, &one, &tv1)
+	FpSub(&tv1, &one, &tv1)
+	FpMul(&tv3, &tv1, &tv2)
+
+	if !tv3.IsZero() {
+		FpInv(&tv3, &tv3)
+	}
+
+	// tv4 = u tv1 tv3 c3
+	FpMul(&tv4, u, &tv1)
+	FpMul(&tv4, &tv4, &tv3)
+	FpMul(&tv4, &tv4, &svdwC3)
+
+	// x1 = c2 - tv4, x2 = c2 + tv4, x3 = Z + c4 (tv2^2 tv3)^2
+	FpSub(&x, &svdwC2, &tv4)
+
+	if !FpSquareRoot(&y, svdwCurveRHS(&x)) {
+		FpAdd(&x, &svdwC2, &tv4)
+
+		if !FpSquareRoot(&y, svdwCurveRHS(&x)) {
+			FpSqr(&x, &tv2)
+			FpMul(&x, &x, &tv3)
+			FpSqr(&x, &x)
+			FpMul(&x, &x, &svdwC4)
+			FpAdd(&x, &x, &one)
+
+			FpSquareRoot(&y, svdwCurveRHS(&x))
+		}
+	}
+
+	// the sign of y is the sign of u
+	if u.IsOdd() != y.IsOdd() {
+		FpNeg(&y, &y)
+	}
+
+	out.X, out.Y = x, y
+	out.Z.SetInt64(1)
+}
+
+// svdwCurveRHS returns x^3 + 3
+func svdwCurveRHS(x *Fp) *Fp {
+	var b Fp
+
+	b.SetInt64(3)
+
+	gx := new(Fp)
+
+	FpSqr(gx, x)
+	FpMul(gx, gx, x)
+	FpAdd(gx, gx, &b)
+
+	return gx
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// RFC 9380 does not define a suite for BN254. These are the BN254G1_XMD:SHA-256_SVDW_RO_ vectors of gnark-crypto,
+// hashToG1Vector in ecc/bn254/hash_vectors_test.go, which follow the format of the RFC 9380 vectors
+func TestHashToCurve_SVDWVectors(t *testing.T) {
+	t.Parallel()
+
+	dst := []byte("QUUX-V01-CS02-with-BN254G1_XMD:SHA-256_SVDW_RO_")
+
+	cases := []struct {
+		msg    string
+		p      string
+		q0, q1 string
+		u0, u1 string
+	}{
+		{
+			msg: "",
+			p:   "a976ab906170db1f9638d376514dbf8c42aef256a54bbd48521f20749e59e86 2925ead66b9e68bfc309b014398640ab55f6619ab59bc1fab2210ad4c4d53d5",
+			q0:  "e449b959abbd0e5ab4c873eaeb1ccd887f1d9ad6cd671fd72cb8d77fb651892 29ff1e36867c60374695ee0c298fcbef2af16f8f97ed356fa75e61a797ebb265",
+			q1:  "19388d9112a306fba595c3a8c63daa8f04205ad9581f7cf105c63c442d7c6511 182da356478aa7776d1de8377a18b41e933036d0b71ab03f17114e4e673ad6e4",
+			u0:  "2f87b81d9d6ef05ad4d249737498cc27e1bd485dca804487844feb3c67c1a9b5",
+			u1:  "6de2d0d7c0d9c7a5a6c0b74675e7543f5b98186b5dbf831067449000b2b1f8e",
+		},
+		{
+			msg: "abc",
+			p:   "23f717bee89b1003957139f193e6be7da1df5f1374b26a4643b0378b5baf53d1 4142f826b71ee574452dbc47e05bc3e1a647478403a7ba38b7b93948f4e151d",
+			q0:  "1452c8cc24f8dedc25b24d89b87b64e25488191cecc78464fea84077dd156f8d 209c3633505ba956f5ce4d974a868db972b8f1b69d63c218d360996bcec1ad41",
+			q1:  "4e8357c98524e6208ae2b771e370f0c449e839003988c2e4ce1eaf8d632559f 4396ec43dd8ec8f2b4a705090b5892219759da30154c39490fc4d59d51bb817",
+			u0:  "11945105b5e3d3b9392b5a2318409cbc28b7246aa47fa30da5739907737799a9",
+			u1:  "1255fc9ad5a6e0fb440916f091229bda611c41be2f2283c3d8f98c596be4c8c9",
+		},
+		{
+			msg: "abcdef0123456789",
+			p:   "187dbf1c3c89aceceef254d6548d7163fdfa43084145f92c4c91c85c21442d4a abd99d5b0000910b56058f9cc3b0ab0a22d47cf27615f588924fac1e5c63b4d",
+			q0:  "28d01790d2a1cc4832296774438acd46c2ce162d03099926478cf52319daba8d 10227ab2707fd65fb45e87f0a48cfe3556f04113d27b1da9a7ae1709007355e1",
+			q1:  "7dc256c7aadac1b4e1d23b3b2bbb5e2ffd9c753b9073d8d952ead8f812ce1b3 2589008b2e15dcb3d16cdc1fed2634778001b1b28f0ab433f4f5ec6635c55e1e",
+			u0:  "2f7993a6b43a8dbb37060e790011a888157f456b895b925c3568690685f4983d",
+			u1:  "2677d0532b47a4cead2488845e7df7ebc16c0b8a2cd8a6b7f4ce99f51659794e",
+		},
+		{
+			msg: "q128_" + strings.Repeat("q", 128),
+			p:   "fe2b0743575324fc452d590d217390ad48e5a16cf051bee5c40a2eba233f5c 794211e0cc72d3cbbdf8e4e5cd6e7d7e78d101ff94862caae8acbe63e9fdc78",
+			q0:  "1c53b05f2fce15ba0b9100650c0fb46de1fb62f1d0968b69151151bd25dfefa4 1fe783faf4bdbd79b717784dc59619106e4acccfe3b5d9750799729d855e7b81",
+			q1:  "214a4e6e97adda47558f80088460eabd71ed35bc8ceafb99a493dd6f4e2b3f0a faaeb29cc23f9d09b187a99741613aed84443e7c35736258f57982d336d13bd",
+			u0:  "2a50be15282ee276b76db1dab761f75401cdc8bd9fff81fcf4d428db16092a7b",
+			u1:  "23b41953676183c30aca54b5c8bd3ffe3535a6238c39f6b15487a5467d5d20eb",
+		},
+		{
+			msg: "a512_" + strings.Repeat("a", 512),
+			p:   "1b05dc540bd79fd0fea4fbb07de08e94fc2e7bd171fe025c479dc212a2173ce 1bf028afc00c0f843d113758968f580640541728cfc6d32ced9779aa613cd9b0",
+			q0:  "2298ba379768da62495af6bb390ffca9156fde1dc167235b89c6dd008d2f2f3b 660564cf6fce5cdea4780f5976dd0932559336fd072b4ddd83ec37f00fc7699",
+			q1:  "2811dea430f7a1f6c8c941ecdf0e1e725b8ad1801ad15e832654bd8f10b62f16 253390ed4fb39e58c30ca43892ab0428684cfb30b9df05fc239ab532eaa02444",
+			u0:  "48527470f534978bae262c0f3ba8380d7f560916af58af9ad7dcb6a4238e633",
+			u1:  "19a6d8be25702820b9b11eada2d42f425343889637a01ecd7672fbcf590d9ffe",
+		},
+	}
+
+	for _, c := range cases {
+		u, err := hashToFpXMDSHA256([]byte(c.msg), dst, 2)
+		require.NoError(t, err)
+
+		assert.Equal(t, c.u0, u[0].GetString(16))
+		assert.Equal(t, c.u1, u[1].GetString(16))
+
+		q := new(G1)
+
+		MapToG1SVDW(q, u[0])
+		assert.Equal(t, "1 "+c.q0, q.GetString(16))
+
+		MapToG1SVDW(q, u[1])
+		assert.Equal(t, "1 "+c.q1, q.GetString(16))
+
+		p, err := HashToG1SVDW([]byte(c.msg), dst)
+		require.NoError(t, err)
+		assert.Equal(t, "1 "+c.p, p.GetString(16))
+	}
+}
+
+func TestHashToCurve_SVDWScheme(t *testing.T) {
+	t.Parallel()
+
+	message := []byte("abc")
+
+	scheme, err := NewScheme(SVDWCiphersuiteID, []byte("BLS_SIG_BN254G1_XMD:SHA-256_SVDW_RO_NUL_"), HashToG1SVDW)
+	require.NoError(t, err)
+
+	key, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	signature, err := scheme.Sign(key, message)
+	require.NoError(t, err)
+
+	assert.True(t, scheme.Verify(signature, key.PublicKey(), message))
+	assert.False(t, scheme.Verify(signature, key.PublicKey(), []byte("abd")))
+
+	// the signatures of the default scheme still verify with it but not with the SVDW scheme
+	legacy, err := key.Sign(message)
+	require.NoError(t, err)
+
+	assert.True(t, legacy.Verify(key.PublicKey(), message))
+	assert.False(t, scheme.Verify(legacy, key.PublicKey(), message))
+}
+
+func TestHashToCurve_SVDWExceptionalCase(t *testing.T) {
+	t.Parallel()
+
+	// u = 1/2 makes tv1 tv2 vanish, so inv0 returns zero and the map must still give a point on the curve
+	var u, two Fp
+
+	u.SetInt64(1)
+	two.SetInt64(2)
+	FpDiv(&u, &u, &two)
+
+	q := new(G1)
+
+	MapToG1SVDW(q, &u)
+	assert.True(t, q.IsValid())
+	assert.False(t, q.IsZero())
+}
@@ -1,3 +1,5 @@
+//go:build cgo && !purego
+
 package core
 
 /*
@@ -675,23 +677,6 @@ func (x *G1) Deserialize(buf []byte) error {
 	return nil
 }
 
-const ZERO_HEADER = 1 << 6
-
-func isZeroFormat(buf []byte, n int) bool {
-	if len(buf) < n {
-		return false
-	}
-	if buf[0] != ZERO_HEADER {
-		return false
-	}
-	for i := 1; i < n; i++ {
-		if buf[i] != 0 {
-			return false
-		}
-	}
-	return true
-}
-
 // DeserializeUncompressed -- x.Deserialize() + y.Deserialize()
 func (x *G1) DeserializeUncompressed(buf []byte) error {
 	if isZeroFormat(buf, GetG1ByteSize()*2) {
@@ -1201,7 +1186,7 @@ func PrecomputedMillerLoop(out *GT, P *G1, Qbuf []uint64) {
 // PrecomputedMillerLoop2 --
 func PrecomputedMillerLoop2(out *GT, P1 *G1, Q1buf []uint64, P2 *G1, Q2buf []uint64) {
 	// #nosec
-	C.mclBn_precomputedMillerLoop2(out.getPointer(), P1.getPointer(), (*C.uint64_t)(unsafe.Pointer(&Q1buf[0])), P1.getPointer(), (*C.uint64_t)(unsafe.Pointer(&Q1buf[0])))
+	C.mclBn_precomputedMillerLoop2(out.getPointer(), P1.getPointer(), (*C.uint64_t)(unsafe.Pointer(&Q1buf[0])), P2.getPointer(), (*C.uint64_t)(unsafe.Pointer(&Q2buf[0])))
 }
 
 // FrEvaluatePolynomial -- y = c[0] + c[1] * x + c[2] * x^2 + ...
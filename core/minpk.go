@@ -0,0 +1,463 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// The minimal public key size variant keeps public keys in G1 and signatures in G2, so a public key
+// takes 64 bytes (32 compressed) and a signature 128 bytes (64 compressed). Messages are hashed by HashToG2
+// under the domain of a SchemeG2. The keys and signatures of both variants cannot be mixed
+
+const (
+	// DefaultG2DST is the domain separation tag of signatures in G2 of the default SchemeG2
+	DefaultG2DST = "BLS_SIG_BN254G2_XMD:SHA-256_FT_RO_NUL_"
+	// DefaultG2PoPDST is the domain separation tag of proofs of possession in G2 of the default SchemeG2
+	DefaultG2PoPDST = "BLS_POP_BN254G2_XMD:SHA-256_FT_RO_POP_"
+)
+
+// SchemeG2 is the minimal public key size scheme with its domain separation tags of signatures
+// and of proofs of possession. It is immutable, so schemes with different tags can be used concurrently
+type SchemeG2 struct {
+	dst    []byte
+	popDST []byte
+}
+
+var defaultSchemeG2 = &SchemeG2{dst: []byte(DefaultG2DST), popDST: []byte(DefaultG2PoPDST)}
+
+// NewSchemeG2 creates the scheme signing messages under dst and public keys under popDST
+func NewSchemeG2(dst, popDST []byte) (*SchemeG2, error) {
+	for _, tag := range [][]byte{dst, popDST} {
+		if len(tag) == 0 || len(tag) > 255 {
+			return nil, errInvalidDST
+		}
+	}
+
+	return &SchemeG2{dst: append([]byte{}, dst...), popDST: append([]byte{}, popDST...)}, nil
+}
+
+// DefaultSchemeG2 returns the scheme used by the methods of PublicKeyG1 and SignatureG2
+func DefaultSchemeG2() *SchemeG2 {
+	return defaultSchemeG2
+}
+
+// DST returns a copy of the domain separation tag of signatures
+func (s *SchemeG2) DST() []byte {
+	return append([]byte{}, s.dst...)
+}
+
+// PoPDST returns a copy of the domain separation tag of proofs of possession
+func (s *SchemeG2) PoPDST() []byte {
+	return append([]byte{}, s.popDST...)
+}
+
+// Sign generates a signature in G2 of the given message
+func (s *SchemeG2) Sign(key *PrivateKey, message []byte) (*SignatureG2, error) {
+	return key.signG2(message, s.dst)
+}
+
+// ProvePossession generates a proof of possession of the private key for its public key in G1
+func (s *SchemeG2) ProvePossession(key *PrivateKey) (*SignatureG2, error) {
+	return key.signG2(key.PublicKeyG1().Marshal(), s.popDST)
+}
+
+// Verify checks the BLS signature of the message against the public key of its signer
+func (s *SchemeG2) Verify(signature *SignatureG2, publicKey *PublicKeyG1, message []byte) bool {
+	return signature.verify(publicKey, message, s.dst)
+}
+
+// VerifyPossession checks the proof of possession generated by ProvePossession
+func (s *SchemeG2) VerifyPossession(publicKey *PublicKeyG1, proof *SignatureG2) bool {
+	if publicKey == nil || publicKey.p == nil {
+		return false
+	}
+
+	return proof.verify(publicKey, publicKey.Marshal(), s.popDST)
+}
+
+// PublicKeyG1 represents bls public key in G1
+type PublicKeyG1 struct {
+	p *G1
+}
+
+// SignatureG2 represents bls signature in G2
+type SignatureG2 struct {
+	p *G2
+}
+
+// NewPublicKeyG1 creates the public key from the given point. The point is not validated
+func NewPublicKeyG1(g1 *G1) *PublicKeyG1 {
+	p := *g1
+
+	return &PublicKeyG1{p: &p}
+}
+
+// PublicKeyG1 returns the public key in G1 from the PrivateKey
+func (p *PrivateKey) PublicKeyG1() *PublicKeyG1 {
+	public := new(G1)
+
+	G1Mul(public, ellipticCurveG1, p.p)
+
+	return &PublicKeyG1{p: public}
+}
+
+// SignG2 generates a signature in G2 of the given message with the default SchemeG2
+func (p *PrivateKey) SignG2(message []byte) (*SignatureG2, error) {
+	return defaultSchemeG2.Sign(p, message)
+}
+
+// ProvePossessionG2 generates a proof of possession of the private key for its public key in G1
+// with the default SchemeG2
+func (p *PrivateKey) ProvePossessionG2() (*SignatureG2, error) {
+	return defaultSchemeG2.ProvePossession(p)
+}
+
+func (p *PrivateKey) signG2(message []byte, domain []byte) (*SignatureG2, error) {
+	messagePoint, err := HashToG2(message, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	g2 := new(G2)
+
+	G2Mul(g2, messagePoint, p.p)
+
+	return &SignatureG2{p: g2}, nil
+}
+
+// Verify checks the BLS signature of the message against the public key of its signer with the default SchemeG2
+func (s *SignatureG2) Verify(publicKey *PublicKeyG1, message []byte) bool {
+	return defaultSchemeG2.Verify(s, publicKey, message)
+}
+
+// VerifyAggregated checks the BLS signature of the message against the aggregated public keys of its signers.
+// It is vulnerable to rogue-key attacks, see FastAggregateVerifyG2
+func (s *SignatureG2) VerifyAggregated(publicKeys []*PublicKeyG1, msg []byte) bool {
+	return s.Verify(AggregatePublicKeysG1(publicKeys), msg)
+}
+
+// verify checks e(g1, s) == e(publicKey, H(message))
+func (s *SignatureG2) verify(publicKey *PublicKeyG1, message []byte, domain []byte) bool {
+	if s == nil || s.p == nil || publicKey == nil || publicKey.p == nil {
+		return false
+	}
+
+	messagePoint, err := HashToG2(message, domain)
+	if err != nil {
+		return false
+	}
+
+	g1s := make([]G1, 2)
+	g2s := []G2{*s.p, *messagePoint}
+
+	G1Neg(&g1s[0], ellipticCurveG1)
+	g1s[1] = *publicKey.p
+
+	e := new(GT)
+
+	MillerLoopVec(e, g1s, g2s)
+	FinalExp(e, e)
+
+	return e.IsOne()
+}
+
+// FastAggregateVerifyG2 checks the aggregated signature with the default SchemeG2, see SchemeG2.FastAggregateVerify
+func FastAggregateVerifyG2(signature *SignatureG2, publicKeys []*PublicKeyG1, message []byte) bool {
+	return defaultSchemeG2.FastAggregateVerify(signature, publicKeys, message)
+}
+
+// AggregateVerifyG2 checks the aggregated signature of distinct messages with the default SchemeG2,
+// see SchemeG2.AggregateVerify
+func AggregateVerifyG2(sig *SignatureG2, pubs []*PublicKeyG1, msgs [][]byte) bool {
+	return defaultSchemeG2.AggregateVerify(sig, pubs, msgs)
+}
+
+// FastAggregateVerify checks the aggregated signature of the same message signed by all of the given keys.
+// It is safe only if every public key has been checked with VerifyPossession
+func (s *SchemeG2) FastAggregateVerify(signature *SignatureG2, publicKeys []*PublicKeyG1, message []byte) bool {
+	if signature == nil || signature.p == nil || len(publicKeys) == 0 {
+		return false
+	}
+
+	for _, pub := range publicKeys {
+		if pub == nil || pub.p == nil {
+			return false
+		}
+	}
+
+	return s.Verify(signature, AggregatePublicKeysG1(publicKeys), message)
+}
+
+// AggregateVerify checks the aggregated signature of distinct messages, where msgs[i] is signed by pubs[i].
+// Duplicate messages are rejected
+func (s *SchemeG2) AggregateVerify(sig *SignatureG2, pubs []*PublicKeyG1, msgs [][]byte) bool {
+	if sig == nil || sig.p == nil || len(pubs) == 0 || len(pubs) != len(msgs) {
+		return false
+	}
+
+	seen := make(map[string]struct{}, len(msgs))
+	g1s := make([]G1, len(pubs)+1)
+	g2s := make([]G2, len(msgs)+1)
+
+	G1Neg(&g1s[0], ellipticCurveG1)
+	g2s[0] = *sig.p
+
+	for i, msg := range msgs {
+		if _, exists := seen[string(msg)]; exists {
+			return false
+		}
+
+		seen[string(msg)] = struct{}{}
+
+		if pubs[i] == nil || pubs[i].p == nil {
+			return false
+		}
+
+		messagePoint, err := HashToG2(msg, s.dst)
+		if err != nil {
+			return false
+		}
+
+		g1s[i+1] = *pubs[i].p
+		g2s[i+1] = *messagePoint
+	}
+
+	e := new(GT)
+
+	MillerLoopVec(e, g1s, g2s)
+	FinalExp(e, e)
+
+	return e.IsOne()
+}
+
+// Aggregate adds the given signatures
+func (s *SignatureG2) Aggregate(next *SignatureG2) *SignatureG2 {
+	newp := new(G2)
+
+	if s.p != nil {
+		G2Add(newp, newp, s.p)
+	}
+
+	if next.p != nil {
+		G2Add(newp, newp, next.p)
+	}
+
+	return &SignatureG2{p: newp}
+}
+
+// Marshal the signature to bytes.
+func (s *SignatureG2) Marshal() ([]byte, error) {
+	if s.p == nil {
+		return nil, errors.New("cannot marshal empty signature")
+	}
+
+	return G2ToBytes(s.p), nil
+}
+
+// MarshalCompressed marshals the signature to 64 bytes
+func (s *SignatureG2) MarshalCompressed() ([]byte, error) {
+	if s.p == nil {
+		return nil, errors.New("cannot marshal empty signature")
+	}
+
+	return G2ToCompressedBytes(s.p), nil
+}
+
+// UnmarshalCompressed reads the compressed signature into s.
+// The point must be in the prime-order subgroup and must not be the identity
+func (s *SignatureG2) UnmarshalCompressed(raw []byte) error {
+	g2, err := G2FromCompressedBytes(raw)
+	if err != nil {
+		return err
+	}
+
+	if err := ValidateG2(g2); err != nil {
+		return err
+	}
+
+	s.p = g2
+
+	return nil
+}
+
+func (s SignatureG2) String() string {
+	return fmt.Sprintf("(%s %s, %s %s, %s %s)",
+		s.p.X.D[0].GetString(16), s.p.X.D[1].GetString(16),
+		s.p.Y.D[0].GetString(16), s.p.Y.D[1].GetString(16),
+		s.p.Z.D[0].GetString(16), s.p.Z.D[1].GetString(16))
+}
+
+// UnmarshalSignatureG2 reads the signature from the given byte array, either compressed or uncompressed
+// depending on its length. The point must be on the curve, in the prime-order subgroup and must not be the identity
+func UnmarshalSignatureG2(raw []byte) (*SignatureG2, error) {
+	g2, err := g2FromBytesOrCompressed(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateG2(g2); err != nil {
+		return nil, err
+	}
+
+	return &SignatureG2{p: g2}, nil
+}
+
+// UnmarshalSignatureG2Unchecked reads the signature from the given byte array without validating the point.
+// It should only be used for trusted data
+func UnmarshalSignatureG2Unchecked(raw []byte) (*SignatureG2, error) {
+	g2, err := g2FromBytesOrCompressed(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignatureG2{p: g2}, nil
+}
+
+// AggregateSignaturesG2 sums the given array of signatures
+func AggregateSignaturesG2(signatures []*SignatureG2) *SignatureG2 {
+	newp := new(G2)
+
+	for _, x := range signatures {
+		if x.p != nil {
+			G2Add(newp, newp, x.p)
+		}
+	}
+
+	return &SignatureG2{p: newp}
+}
+
+// Aggregate aggregates current key with key passed as a parameter
+func (p *PublicKeyG1) Aggregate(next *PublicKeyG1) *PublicKeyG1 {
+	newp := new(G1)
+
+	if p.p != nil {
+		G1Add(newp, newp, p.p)
+	}
+
+	if next.p != nil {
+		G1Add(newp, newp, next.p)
+	}
+
+	return &PublicKeyG1{p: newp}
+}
+
+// VerifyPossession checks the proof of possession generated by PrivateKey.ProvePossessionG2
+// with the default SchemeG2
+func (p *PublicKeyG1) VerifyPossession(proof *SignatureG2) bool {
+	return defaultSchemeG2.VerifyPossession(p, proof)
+}
+
+// Marshal marshals public key to bytes.
+func (p *PublicKeyG1) Marshal() []byte {
+	if p.p == nil {
+		return nil
+	}
+
+	return G1ToBytes(p.p)
+}
+
+// MarshalCompressed marshals the public key to 32 bytes
+func (p *PublicKeyG1) MarshalCompressed() []byte {
+	if p.p == nil {
+		return nil
+	}
+
+	return G1ToCompressedBytes(p.p)
+}
+
+// UnmarshalCompressed reads the compressed public key into p.
+// The point must be in the prime-order subgroup and must not be the identity
+func (p *PublicKeyG1) UnmarshalCompressed(raw []byte) error {
+	g1, err := G1FromCompressedBytes(raw)
+	if err != nil {
+		return err
+	}
+
+	if err := ValidateG1(g1); err != nil {
+		return err
+	}
+
+	p.p = g1
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (p *PublicKeyG1) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.Marshal())
+}
+
+// UnmarshalJSON implements the json.Marshaler interface.
+func (p *PublicKeyG1) UnmarshalJSON(raw []byte) error {
+	var jsonBytes []byte
+
+	if err := json.Unmarshal(raw, &jsonBytes); err != nil {
+		return err
+	}
+
+	pub, err := UnmarshalPublicKeyG1(jsonBytes)
+	if err != nil {
+		return err
+	}
+
+	p.p = pub.p
+
+	return nil
+}
+
+func (p PublicKeyG1) String() string {
+	return fmt.Sprintf("(%s, %s, %s)",
+		p.p.X.GetString(16), p.p.Y.GetString(16), p.p.Z.GetString(16))
+}
+
+// UnmarshalPublicKeyG1 reads the public key from the given byte array, either compressed or uncompressed
+// depending on its length. The point must be on the curve, in the prime-order subgroup and must not be the identity
+func UnmarshalPublicKeyG1(raw []byte) (*PublicKeyG1, error) {
+	g1, err := g1FromBytesOrCompressed(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateG1(g1); err != nil {
+		return nil, err
+	}
+
+	return &PublicKeyG1{p: g1}, nil
+}
+
+// UnmarshalPublicKeyG1Unchecked reads the public key from the given byte array without validating the point.
+// It should only be used for trusted data
+func UnmarshalPublicKeyG1Unchecked(raw []byte) (*PublicKeyG1, error) {
+	g1, err := g1FromBytesOrCompressed(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PublicKeyG1{p: g1}, nil
+}
+
+// CollectPublicKeysG1 collects public keys in G1 from slice of private keys
+func CollectPublicKeysG1(keys []*PrivateKey) []*PublicKeyG1 {
+	pubKeys := make([]*PublicKeyG1, len(keys))
+
+	for i, key := range keys {
+		pubKeys[i] = key.PublicKeyG1()
+	}
+
+	return pubKeys
+}
+
+// AggregatePublicKeysG1 calculates P1 + P2 + ...
+// The aggregated key is vulnerable to rogue-key attacks unless every key has been checked with VerifyPossession
+func AggregatePublicKeysG1(pubs []*PublicKeyG1) *PublicKeyG1 {
+	newp := new(G1)
+
+	for _, x := range pubs {
+		if x.p != nil {
+			G1Add(newp, newp, x.p)
+		}
+	}
+
+	return &PublicKeyG1{p: newp}
+}
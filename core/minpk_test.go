@@ -0,0 +1,193 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinPk_SignVerify(t *testing.T) {
+	t.Parallel()
+
+	validTestMsg, invalidTestMsg := testGenRandomBytes(t, messageSize), testGenRandomBytes(t, messageSize)
+
+	key, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	signature, err := key.SignG2(validTestMsg)
+	require.NoError(t, err)
+
+	assert.True(t, signature.Verify(key.PublicKeyG1(), validTestMsg))
+	assert.False(t, signature.Verify(key.PublicKeyG1(), invalidTestMsg))
+
+	other, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	assert.False(t, signature.Verify(other.PublicKeyG1(), validTestMsg))
+
+	// the message points are in G2 and differ from the ones of the G1 signatures
+	messagePoint, err := HashToG2(validTestMsg, []byte(DefaultG2DST))
+	require.NoError(t, err)
+	assert.NoError(t, ValidateG2(messagePoint))
+
+	otherPoint, err := HashToG2(validTestMsg, []byte(DefaultG2PoPDST))
+	require.NoError(t, err)
+	assert.False(t, messagePoint.IsEqual(otherPoint))
+}
+
+func TestMinPk_Aggregate(t *testing.T) {
+	t.Parallel()
+
+	validTestMsg, invalidTestMsg := testGenRandomBytes(t, messageSize), testGenRandomBytes(t, messageSize)
+
+	keys, err := CreateRandomBlsKeys(participantsNumber)
+	require.NoError(t, err)
+
+	pubKeys := CollectPublicKeysG1(keys)
+	signatures := make([]*SignatureG2, len(keys))
+	aggSignature := &SignatureG2{}
+
+	for i, key := range keys {
+		proof, err := key.ProvePossessionG2()
+		require.NoError(t, err)
+		assert.True(t, pubKeys[i].VerifyPossession(proof))
+
+		signatures[i], err = key.SignG2(validTestMsg)
+		require.NoError(t, err)
+
+		aggSignature = aggSignature.Aggregate(signatures[i])
+	}
+
+	assert.True(t, aggSignature.VerifyAggregated(pubKeys, validTestMsg))
+	assert.False(t, aggSignature.VerifyAggregated(pubKeys, invalidTestMsg))
+	assert.True(t, FastAggregateVerifyG2(AggregateSignaturesG2(signatures), pubKeys, validTestMsg))
+	assert.False(t, FastAggregateVerifyG2(AggregateSignaturesG2(signatures[1:]), pubKeys, validTestMsg))
+	assert.False(t, FastAggregateVerifyG2(aggSignature, nil, validTestMsg))
+
+	// distinct messages
+	msgs := make([][]byte, len(keys))
+
+	for i, key := range keys {
+		msgs[i] = testGenRandomBytes(t, messageSize)
+
+		signatures[i], err = key.SignG2(msgs[i])
+		require.NoError(t, err)
+	}
+
+	aggSignature = AggregateSignaturesG2(signatures)
+
+	assert.True(t, AggregateVerifyG2(aggSignature, pubKeys, msgs))
+	assert.False(t, AggregateVerifyG2(aggSignature, pubKeys[1:], msgs[1:]))
+
+	msgs[1] = msgs[0]
+	assert.False(t, AggregateVerifyG2(aggSignature, pubKeys, msgs))
+}
+
+func TestMinPk_PossessionOfOtherKey(t *testing.T) {
+	t.Parallel()
+
+	keys, err := CreateRandomBlsKeys(2)
+	require.NoError(t, err)
+
+	proof, err := keys[0].ProvePossessionG2()
+	require.NoError(t, err)
+
+	assert.False(t, keys[1].PublicKeyG1().VerifyPossession(proof))
+
+	// a signature of the public key under the signing domain is not a proof of possession
+	signature, err := keys[0].SignG2(keys[0].PublicKeyG1().Marshal())
+	require.NoError(t, err)
+
+	assert.False(t, keys[0].PublicKeyG1().VerifyPossession(signature))
+}
+
+func TestMinPk_Scheme(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	message := []byte("message")
+
+	scheme, err := NewSchemeG2([]byte("BLS_SIG_OTHER_"), []byte("BLS_POP_OTHER_"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte(DefaultG2DST), DefaultSchemeG2().DST())
+	assert.Equal(t, []byte("BLS_POP_OTHER_"), scheme.PoPDST())
+
+	signature, err := scheme.Sign(key, message)
+	require.NoError(t, err)
+
+	proof, err := scheme.ProvePossession(key)
+	require.NoError(t, err)
+
+	// the schemes are separated by their tags
+	assert.True(t, scheme.Verify(signature, key.PublicKeyG1(), message))
+	assert.True(t, scheme.FastAggregateVerify(signature, []*PublicKeyG1{key.PublicKeyG1()}, message))
+	assert.True(t, scheme.AggregateVerify(signature, []*PublicKeyG1{key.PublicKeyG1()}, [][]byte{message}))
+	assert.False(t, signature.Verify(key.PublicKeyG1(), message))
+
+	assert.True(t, scheme.VerifyPossession(key.PublicKeyG1(), proof))
+	assert.False(t, key.PublicKeyG1().VerifyPossession(proof))
+
+	_, err = NewSchemeG2([]byte("DST"), nil)
+	assert.ErrorIs(t, err, errInvalidDST)
+}
+
+func TestMinPk_Marshal(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	pub := key.PublicKeyG1()
+
+	assert.Len(t, pub.Marshal(), 64)
+	assert.Len(t, pub.MarshalCompressed(), G1CompressedSize)
+
+	for _, raw := range [][]byte{pub.Marshal(), pub.MarshalCompressed()} {
+		unmarshaled, err := UnmarshalPublicKeyG1(raw)
+		require.NoError(t, err)
+		assert.Equal(t, pub.Marshal(), unmarshaled.Marshal())
+	}
+
+	decompressed := new(PublicKeyG1)
+	require.NoError(t, decompressed.UnmarshalCompressed(pub.MarshalCompressed()))
+	assert.Equal(t, pub.Marshal(), decompressed.Marshal())
+
+	jsonRaw, err := json.Marshal(pub)
+	require.NoError(t, err)
+
+	fromJSON := new(PublicKeyG1)
+	require.NoError(t, json.Unmarshal(jsonRaw, fromJSON))
+	assert.Equal(t, pub.Marshal(), fromJSON.Marshal())
+
+	signature, err := key.SignG2([]byte("abc"))
+	require.NoError(t, err)
+
+	raw, err := signature.Marshal()
+	require.NoError(t, err)
+	assert.Len(t, raw, 128)
+
+	compressed, err := signature.MarshalCompressed()
+	require.NoError(t, err)
+	assert.Len(t, compressed, G2CompressedSize)
+
+	for _, raw := range [][]byte{raw, compressed} {
+		unmarshaled, err := UnmarshalSignatureG2(raw)
+		require.NoError(t, err)
+		assert.True(t, unmarshaled.Verify(pub, []byte("abc")))
+	}
+
+	// the identity is rejected
+	_, err = UnmarshalPublicKeyG1(make([]byte, 64))
+	assert.ErrorIs(t, err, ErrIdentityPoint)
+
+	_, err = UnmarshalSignatureG2(make([]byte, 128))
+	assert.ErrorIs(t, err, ErrIdentityPoint)
+
+	_, err = new(SignatureG2).Marshal()
+	assert.Error(t, err)
+}
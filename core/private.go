@@ -13,6 +13,20 @@ type PrivateKey struct {
 	p *Fr
 }
 
+// NewPrivateKey creates the private key from the given scalar
+func NewPrivateKey(fr *Fr) *PrivateKey {
+	p := *fr
+
+	return &PrivateKey{p: &p}
+}
+
+// Scalar returns a copy of the scalar of the private key
+func (p *PrivateKey) Scalar() *Fr {
+	fr := *p.p
+
+	return &fr
+}
+
 // PublicKey returns the public key from the PrivateKey
 func (p *PrivateKey) PublicKey() *PublicKey {
 	public := new(G2)
@@ -24,19 +38,16 @@ func (p *PrivateKey) PublicKey() *PublicKey {
 
 // Sign generates a signature of the given message
 func (p *PrivateKey) Sign(message []byte) (*Signature, error) {
-	messagePoint, err := HashToG1(message)
-	if err != nil {
-		return nil, err
-	}
-
-	g1 := new(G1)
-
-	G1Mul(g1, messagePoint, p.p)
+	return defaultScheme.Sign(p, message)
+}
 
-	return &Signature{p: g1}, nil
+// ProvePossession generates a proof of possession of the private key with the default scheme,
+// see Scheme.ProvePossession
+func (p *PrivateKey) ProvePossession() (*Signature, error) {
+	return defaultScheme.ProvePossession(p)
 }
 
-// MarshalJSON marshal the key to bytes.
+// MarshalJSON marshal the key to bytes. The bytes are not encrypted, use keystore.Encrypt to store the key
 func (p *PrivateKey) MarshalJSON() ([]byte, error) {
 	if p.p == nil {
 		return nil, errEmptyKeyMarshalling
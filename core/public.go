@@ -10,6 +10,23 @@ type PublicKey struct {
 	p *G2
 }
 
+// NewPublicKey creates the public key from the given point. The point is not validated
+func NewPublicKey(g2 *G2) *PublicKey {
+	p := *g2
+
+	return &PublicKey{p: &p}
+}
+
+// Clone returns a copy of the public key which does not share its point, e.g. to marshal it
+// without normalizing the point of the original
+func (p *PublicKey) Clone() *PublicKey {
+	if p.p == nil {
+		return &PublicKey{}
+	}
+
+	return NewPublicKey(p.p)
+}
+
 // Aggregate aggregates current key with key passed as a parameter
 func (p *PublicKey) Aggregate(next *PublicKey) *PublicKey {
 	newp := new(G2)
@@ -27,6 +44,11 @@ func (p *PublicKey) Aggregate(next *PublicKey) *PublicKey {
 	return &PublicKey{p: newp}
 }
 
+// VerifyPossession checks the proof of possession generated by PrivateKey.ProvePossession with the default scheme
+func (p *PublicKey) VerifyPossession(proof *Signature) bool {
+	return defaultScheme.VerifyPossession(p, proof)
+}
+
 // Marshal marshals public key to bytes.
 func (p *PublicKey) Marshal() []byte {
 	if p.p == nil {
@@ -36,6 +58,32 @@ func (p *PublicKey) Marshal() []byte {
 	return G2ToBytes(p.p)
 }
 
+// MarshalCompressed marshals the public key to 64 bytes
+func (p *PublicKey) MarshalCompressed() []byte {
+	if p.p == nil {
+		return nil
+	}
+
+	return G2ToCompressedBytes(p.p)
+}
+
+// UnmarshalCompressed reads the compressed public key into p.
+// The point must be in the prime-order subgroup and must not be the identity
+func (p *PublicKey) UnmarshalCompressed(raw []byte) error {
+	g2, err := G2FromCompressedBytes(raw)
+	if err != nil {
+		return err
+	}
+
+	if err := ValidateG2(g2); err != nil {
+		return err
+	}
+
+	p.p = g2
+
+	return nil
+}
+
 // MarshalJSON implements the json.Marshaler interface.
 func (p *PublicKey) MarshalJSON() ([]byte, error) {
 	return json.Marshal(p.Marshal())
@@ -57,24 +105,50 @@ func (p *PublicKey) UnmarshalJSON(raw []byte) error {
 		return err
 	}
 
-	p.p, err = G2FromBytes(jsonBytes)
+	pub, err := UnmarshalPublicKey(jsonBytes)
 	if err != nil {
 		return err
 	}
 
+	p.p = pub.p
+
 	return nil
 }
 
-// UnmarshalPublicKey reads the public key from the given byte array
+// UnmarshalPublicKey reads the public key from the given byte array, either compressed or uncompressed
+// depending on its length. The point must be on the curve, in the prime-order subgroup and must not be the identity
 func UnmarshalPublicKey(raw []byte) (*PublicKey, error) {
-	g2, err := G2FromBytes(raw)
+	g2, err := g2FromBytesOrCompressed(raw)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := ValidateG2(g2); err != nil {
+		return nil, err
+	}
+
 	return &PublicKey{p: g2}, nil
 }
 
+// UnmarshalPublicKeyUnchecked reads the public key from the given byte array without validating the point.
+// It should only be used for trusted data, e.g. keys previously validated and stored locally
+func UnmarshalPublicKeyUnchecked(raw []byte) (*PublicKey, error) {
+	g2, err := g2FromBytesOrCompressed(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PublicKey{p: g2}, nil
+}
+
+func g2FromBytesOrCompressed(raw []byte) (*G2, error) {
+	if len(raw) == G2CompressedSize {
+		return G2FromCompressedBytes(raw)
+	}
+
+	return G2FromBytes(raw)
+}
+
 // CollectPublicKeys colects public keys from slice of private keys
 func CollectPublicKeys(keys []*PrivateKey) []*PublicKey {
 	pubKeys := make([]*PublicKey, len(keys))
@@ -87,6 +161,7 @@ func CollectPublicKeys(keys []*PrivateKey) []*PublicKey {
 }
 
 // AggregatePublicKeys calculates P1 + P2 + ...
+// The aggregated key is vulnerable to rogue-key attacks unless every key has been checked with VerifyPossession
 func AggregatePublicKeys(pubs []*PublicKey) *PublicKey {
 	newp := new(G2)
 
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -47,3 +48,105 @@ func TestPublic_MarshalUnmarshalJSON(t *testing.T) {
 	assert.Equal(t, pubKey, newPubKey)
 	require.Equal(t, marshaledPubKey, dt)
 }
+
+func TestPublic_UnmarshalInvalidPoint(t *testing.T) {
+	t.Parallel()
+
+	_, err := UnmarshalPublicKey(make([]byte, 128))
+	assert.ErrorIs(t, err, ErrIdentityPoint)
+
+	notOnCurve := new(G2)
+	notOnCurve.X.D[0].SetInt64(1)
+	notOnCurve.Y.D[0].SetInt64(1)
+	notOnCurve.Z.D[0].SetInt64(1)
+
+	_, err = UnmarshalPublicKey(G2ToBytes(notOnCurve))
+	assert.ErrorIs(t, err, ErrPointNotOnCurve)
+
+	notInSubgroup := testG2PointOutsideSubgroup(t)
+	raw := G2ToBytes(notInSubgroup)
+
+	_, err = UnmarshalPublicKey(raw)
+	assert.ErrorIs(t, err, ErrNotInSubgroup)
+
+	jsonRaw, err := json.Marshal(raw)
+	require.NoError(t, err)
+	assert.ErrorIs(t, new(PublicKey).UnmarshalJSON(jsonRaw), ErrNotInSubgroup)
+
+	// trusted data is not validated
+	pub, err := UnmarshalPublicKeyUnchecked(raw)
+	require.NoError(t, err)
+	assert.True(t, pub.p.IsEqual(notInSubgroup))
+}
+
+// testG2PointOutsideSubgroup finds a point on the twist curve y^2 = x^3 + 3/(9+i) which is not in G2
+func testG2PointOutsideSubgroup(t *testing.T) *G2 {
+	t.Helper()
+
+	var three, xi, b Fp2
+
+	three.D[0].SetInt64(3)
+	xi.D[0].SetInt64(9)
+	xi.D[1].SetInt64(1)
+	Fp2Div(&b, &three, &xi)
+
+	for i := int64(1); i < 100; i++ {
+		g2 := new(G2)
+		g2.X.D[0].SetInt64(i)
+		g2.Z.D[0].SetInt64(1)
+
+		var y2 Fp2
+
+		Fp2Sqr(&y2, &g2.X)
+		Fp2Mul(&y2, &y2, &g2.X)
+		Fp2Add(&y2, &y2, &b)
+
+		if Fp2SquareRoot(&g2.Y, &y2) && g2.IsValid() && !g2.IsValidOrder() {
+			return g2
+		}
+	}
+
+	t.Fatal("point outside of the subgroup not found")
+
+	return nil
+}
+
+func TestPublic_MarshalCompressed(t *testing.T) {
+	t.Parallel()
+
+	keys, err := CreateRandomBlsKeys(8)
+	require.NoError(t, err)
+
+	for _, key := range keys {
+		pub := key.PublicKey()
+
+		compressed := pub.MarshalCompressed()
+		require.Len(t, compressed, G2CompressedSize)
+
+		decoded := new(PublicKey)
+		require.NoError(t, decoded.UnmarshalCompressed(compressed))
+		assert.Equal(t, pub.Marshal(), decoded.Marshal())
+
+		// the length selects the encoding
+		decoded, err = UnmarshalPublicKey(compressed)
+		require.NoError(t, err)
+		assert.Equal(t, pub.Marshal(), decoded.Marshal())
+	}
+
+	identity := G2ToCompressedBytes(new(G2))
+	assert.Equal(t, byte(compressedInfinityFlag), identity[G2CompressedSize-1])
+
+	_, err = UnmarshalPublicKey(identity)
+	assert.ErrorIs(t, err, ErrIdentityPoint)
+
+	raw := G2ToCompressedBytes(testG2PointOutsideSubgroup(t))
+
+	assert.ErrorIs(t, new(PublicKey).UnmarshalCompressed(raw), ErrNotInSubgroup)
+
+	// trusted data is not validated
+	pub, err := UnmarshalPublicKeyUnchecked(raw)
+	require.NoError(t, err)
+	assert.True(t, pub.p.IsEqual(testG2PointOutsideSubgroup(t)))
+
+	assert.Nil(t, new(PublicKey).MarshalCompressed())
+}
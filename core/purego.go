@@ -0,0 +1,1441 @@
+//go:build !cgo || purego
+
+package core
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// This file is the pure Go counterpart of mcl.go. It implements the subset of mcl used for the
+// SNARK1 curve with the same API, the same Montgomery layout of the field elements and the same
+// serialization, hashing and mapping to the curve, so both backends produce identical bytes
+
+// CurveFp254BNb -- 254 bit curve
+const CurveFp254BNb = 0
+
+// 254-bit BN curve with support for roots of unity
+const CurveSNARK1 = 4
+
+// CurveFp382_1 -- 382 bit curve 1
+const CurveFp382_1 = 1
+
+// CurveFp382_2 -- 382 bit curve 2
+const CurveFp382_2 = 2
+
+// BLS12_381 --
+const BLS12_381 = 5
+
+// IRTF -- for SetMapToMode
+const IRTF = 5 /* MCL_MAP_TO_MODE_HASH_TO_CURVE_07 */
+
+const fpByteSize = 32
+
+// maxModInputSize is the largest input of SetLittleEndianMod and SetBigEndianMod, twice the size of Fp as in mcl
+const maxModInputSize = 2 * fpByteSize
+
+var (
+	verifyOrderG1Flag bool
+	verifyOrderG2Flag bool
+)
+
+// InitCurve -- only CurveSNARK1 is supported by the pure Go backend
+func InitCurve(curve int) error {
+	if curve != CurveSNARK1 {
+		return fmt.Errorf("InitCurve curve %d is not supported by the pure Go backend", curve)
+	}
+
+	return nil
+}
+
+// GetFrUnitSize --
+func GetFrUnitSize() int {
+	return fpUint64Num
+}
+
+// GetFpUnitSize --
+// same as GetMaxOpUnitSize()
+func GetFpUnitSize() int {
+	return fpUint64Num
+}
+
+// GetMaxOpUnitSize --
+func GetMaxOpUnitSize() int {
+	return fpUint64Num
+}
+
+// GetOpUnitSize --
+// the length of Fr is GetOpUnitSize() * 8 bytes
+func GetOpUnitSize() int {
+	return fpUint64Num
+}
+
+// GetFrByteSize -- the serialized size of Fr
+func GetFrByteSize() int {
+	return fpByteSize
+}
+
+// GetFpByteSize -- the serialized size of Fp
+func GetFpByteSize() int {
+	return fpByteSize
+}
+
+// GetG1ByteSize -- the serialized size of G1
+func GetG1ByteSize() int {
+	return GetFpByteSize()
+}
+
+// GetG2ByteSize -- the serialized size of G2
+func GetG2ByteSize() int {
+	return GetFpByteSize() * 2
+}
+
+// GetCurveOrder --
+// return the order of G1
+func GetCurveOrder() string {
+	return frField.modulusBig.String()
+}
+
+// GetFieldOrder --
+// return the characteristic of the field where a curve is defined
+func GetFieldOrder() string {
+	return fpField.modulusBig.String()
+}
+
+// VerifyOrderG1 -- verify order if SetString/Deserialize are called
+func VerifyOrderG1(doVerify bool) {
+	verifyOrderG1Flag = doVerify
+}
+
+// VerifyOrderG2 -- verify order if SetString/Deserialize are called
+func VerifyOrderG2(doVerify bool) {
+	verifyOrderG2Flag = doVerify
+}
+
+// SetETHserialization -- the pure Go backend only supports the default serialization
+func SetETHserialization(enable bool) {
+	if enable {
+		panic("SetETHserialization is not supported by the pure Go backend")
+	}
+}
+
+// SetMapToMode -- only the default mode 0 is supported by the pure Go backend
+func SetMapToMode(mode int) error {
+	if mode != 0 {
+		return fmt.Errorf("SetMapToMode mode=%d\n", mode)
+	}
+
+	return nil
+}
+
+// SetDstG1 -- not supported by the pure Go backend
+func SetDstG1(s string) error {
+	return fmt.Errorf("err SetDstG1 is not supported by the pure Go backend")
+}
+
+// SetDstG2 -- not supported by the pure Go backend
+func SetDstG2(s string) error {
+	return fmt.Errorf("err SetDstG2 is not supported by the pure Go backend")
+}
+
+// Fr --
+type Fr struct {
+	v fe
+}
+
+// Clear --
+func (x *Fr) Clear() {
+	x.v = fe{}
+}
+
+// SetInt64 --
+func (x *Fr) SetInt64(v int64) {
+	frField.setInt64(&x.v, v)
+}
+
+// SetString --
+func (x *Fr) SetString(s string, base int) error {
+	if !frField.setString(&x.v, s, base) {
+		return fmt.Errorf("err Fr.SetString %s", s)
+	}
+
+	return nil
+}
+
+// Deserialize --
+func (x *Fr) Deserialize(buf []byte) error {
+	if !frField.deserialize(&x.v, buf) {
+		return fmt.Errorf("err Fr.Deserialize %x", buf)
+	}
+
+	return nil
+}
+
+// SetLittleEndian --
+func (x *Fr) SetLittleEndian(buf []byte) error {
+	frField.setLittleEndianMask(&x.v, buf)
+
+	return nil
+}
+
+// SetLittleEndianMod --
+func (x *Fr) SetLittleEndianMod(buf []byte) error {
+	if len(buf) > maxModInputSize {
+		return fmt.Errorf("err Fr.SetLittleEndianMod %x", buf)
+	}
+
+	x.v = *frField.fromBig(new(big.Int).SetBytes(reverseBytes(buf)))
+
+	return nil
+}
+
+// SetBigEndianMod --
+func (x *Fr) SetBigEndianMod(buf []byte) error {
+	if len(buf) > maxModInputSize {
+		return fmt.Errorf("err Fr.SetBigEndianMod %x", buf)
+	}
+
+	x.v = *frField.fromBig(new(big.Int).SetBytes(buf))
+
+	return nil
+}
+
+// IsEqual --
+func (x *Fr) IsEqual(rhs *Fr) bool {
+	return x.v == rhs.v
+}
+
+// IsZero --
+func (x *Fr) IsZero() bool {
+	return frField.isZero(&x.v)
+}
+
+// IsValid --
+func (x *Fr) IsValid() bool {
+	return frField.isBelowModulus(&x.v)
+}
+
+// IsOne --
+func (x *Fr) IsOne() bool {
+	return frField.isOne(&x.v)
+}
+
+// IsOdd --
+func (x *Fr) IsOdd() bool {
+	return frField.isOdd(&x.v)
+}
+
+// IsNegative -- true if x >= (r + 1) / 2
+func (x *Fr) IsNegative() bool {
+	return frField.isNegative(&x.v)
+}
+
+// SetByCSPRNG --
+func (x *Fr) SetByCSPRNG() bool {
+	return frField.setByCSPRNG(&x.v)
+}
+
+// SetHashOf --
+func (x *Fr) SetHashOf(buf []byte) bool {
+	h := sha256.Sum256(buf)
+	frField.setLittleEndianMask(&x.v, h[:])
+
+	return true
+}
+
+// GetString --
+func (x *Fr) GetString(base int) string {
+	s := frField.getString(&x.v, base)
+	if s == "" {
+		panic("err Fr.GetString")
+	}
+
+	return s
+}
+
+// Serialize --
+func (x *Fr) Serialize() []byte {
+	return frField.serialize(&x.v)
+}
+
+// FrNeg --
+func FrNeg(out *Fr, x *Fr) {
+	frField.neg(&out.v, &x.v)
+}
+
+// FrInv --
+func FrInv(out *Fr, x *Fr) {
+	frField.inverse(&out.v, &x.v)
+}
+
+// FrSqr --
+func FrSqr(out *Fr, x *Fr) {
+	frField.sqr(&out.v, &x.v)
+}
+
+// FrAdd --
+func FrAdd(out *Fr, x *Fr, y *Fr) {
+	frField.add(&out.v, &x.v, &y.v)
+}
+
+// FrSub --
+func FrSub(out *Fr, x *Fr, y *Fr) {
+	frField.sub(&out.v, &x.v, &y.v)
+}
+
+// FrMul --
+func FrMul(out *Fr, x *Fr, y *Fr) {
+	frField.mul(&out.v, &x.v, &y.v)
+}
+
+// FrDiv --
+func FrDiv(out *Fr, x *Fr, y *Fr) {
+	frField.div(&out.v, &x.v, &y.v)
+}
+
+// FrSquareRoot --
+func FrSquareRoot(out *Fr, x *Fr) bool {
+	return frField.sqrt(&out.v, &x.v)
+}
+
+// Fp --
+type Fp struct {
+	v fe
+}
+
+func newFp(a, b, c, d uint64) Fp {
+	return Fp{v: fe{a, b, c, d}}
+}
+
+// Clear --
+func (x *Fp) Clear() {
+	x.v = fe{}
+}
+
+// SetInt64 --
+func (x *Fp) SetInt64(v int64) {
+	fpField.setInt64(&x.v, v)
+}
+
+// SetString --
+func (x *Fp) SetString(s string, base int) error {
+	if !fpField.setString(&x.v, s, base) {
+		return fmt.Errorf("err Fp.SetString %s", s)
+	}
+
+	return nil
+}
+
+// Deserialize --
+func (x *Fp) Deserialize(buf []byte) error {
+	if !fpField.deserialize(&x.v, buf) {
+		return fmt.Errorf("err Fp.Deserialize %x", buf)
+	}
+
+	return nil
+}
+
+// SetLittleEndian --
+func (x *Fp) SetLittleEndian(buf []byte) error {
+	fpField.setLittleEndianMask(&x.v, buf)
+
+	return nil
+}
+
+// SetLittleEndianMod --
+func (x *Fp) SetLittleEndianMod(buf []byte) error {
+	if len(buf) > maxModInputSize {
+		return fmt.Errorf("err Fp.SetLittleEndianMod %x", buf)
+	}
+
+	x.v = *fpField.fromBig(new(big.Int).SetBytes(reverseBytes(buf)))
+
+	return nil
+}
+
+// SetBigEndianMod --
+func (x *Fp) SetBigEndianMod(buf []byte) error {
+	if len(buf) > maxModInputSize {
+		return fmt.Errorf("err Fp.SetBigEndianMod %x", buf)
+	}
+
+	x.v = *fpField.fromBig(new(big.Int).SetBytes(buf))
+
+	return nil
+}
+
+// IsEqual --
+func (x *Fp) IsEqual(rhs *Fp) bool {
+	return x.v == rhs.v
+}
+
+// IsZero --
+func (x *Fp) IsZero() bool {
+	return fpField.isZero(&x.v)
+}
+
+// IsValid --
+func (x *Fp) IsValid() bool {
+	return fpField.isBelowModulus(&x.v)
+}
+
+// IsOne --
+func (x *Fp) IsOne() bool {
+	return fpField.isOne(&x.v)
+}
+
+// IsOdd --
+func (x *Fp) IsOdd() bool {
+	return fpField.isOdd(&x.v)
+}
+
+// IsNegative -- true if x >= (p + 1) / 2
+func (x *Fp) IsNegative() bool {
+	return fpField.isNegative(&x.v)
+}
+
+// SetByCSPRNG --
+func (x *Fp) SetByCSPRNG() {
+	if !fpField.setByCSPRNG(&x.v) {
+		panic("err Fp.SetByCSPRNG")
+	}
+}
+
+// SetHashOf --
+func (x *Fp) SetHashOf(buf []byte) bool {
+	h := sha256.Sum256(buf)
+	fpField.setLittleEndianMask(&x.v, h[:])
+
+	return true
+}
+
+// GetString --
+func (x *Fp) GetString(base int) string {
+	s := fpField.getString(&x.v, base)
+	if s == "" {
+		panic("err Fp.GetString")
+	}
+
+	return s
+}
+
+// Serialize --
+func (x *Fp) Serialize() []byte {
+	return fpField.serialize(&x.v)
+}
+
+// FpNeg --
+func FpNeg(out *Fp, x *Fp) {
+	fpField.neg(&out.v, &x.v)
+}
+
+// FpInv --
+func FpInv(out *Fp, x *Fp) {
+	fpField.inverse(&out.v, &x.v)
+}
+
+// FpSqr --
+func FpSqr(out *Fp, x *Fp) {
+	fpField.sqr(&out.v, &x.v)
+}
+
+// FpAdd --
+func FpAdd(out *Fp, x *Fp, y *Fp) {
+	fpField.add(&out.v, &x.v, &y.v)
+}
+
+// FpSub --
+func FpSub(out *Fp, x *Fp, y *Fp) {
+	fpField.sub(&out.v, &x.v, &y.v)
+}
+
+// FpMul --
+func FpMul(out *Fp, x *Fp, y *Fp) {
+	fpField.mul(&out.v, &x.v, &y.v)
+}
+
+// FpDiv --
+func FpDiv(out *Fp, x *Fp, y *Fp) {
+	fpField.div(&out.v, &x.v, &y.v)
+}
+
+// FpSquareRoot --
+func FpSquareRoot(out *Fp, x *Fp) bool {
+	return fpField.sqrt(&out.v, &x.v)
+}
+
+// Fp2 -- x = D[0] + D[1] i where i^2 = -1
+type Fp2 struct {
+	D [2]Fp
+}
+
+// Clear --
+func (x *Fp2) Clear() {
+	*x = Fp2{}
+}
+
+// Deserialize --
+func (x *Fp2) Deserialize(buf []byte) error {
+	var t Fp2
+
+	if len(buf) != 2*fpByteSize ||
+		!fpField.deserialize(&t.D[0].v, buf[:fpByteSize]) ||
+		!fpField.deserialize(&t.D[1].v, buf[fpByteSize:]) {
+		return fmt.Errorf("err Fp2.Deserialize %x", buf)
+	}
+
+	*x = t
+
+	return nil
+}
+
+// IsEqual --
+func (x *Fp2) IsEqual(rhs *Fp2) bool {
+	return *x == *rhs
+}
+
+// IsZero --
+func (x *Fp2) IsZero() bool {
+	return fp2IsZero(x)
+}
+
+// IsOne --
+func (x *Fp2) IsOne() bool {
+	return fp2IsOne(x)
+}
+
+// Serialize --
+func (x *Fp2) Serialize() []byte {
+	return append(x.D[0].Serialize(), x.D[1].Serialize()...)
+}
+
+// Fp2Neg --
+func Fp2Neg(out *Fp2, x *Fp2) {
+	fp2Neg(out, x)
+}
+
+// Fp2Inv --
+func Fp2Inv(out *Fp2, x *Fp2) {
+	fp2Inv(out, x)
+}
+
+// Fp2Sqr --
+func Fp2Sqr(out *Fp2, x *Fp2) {
+	fp2Sqr(out, x)
+}
+
+// Fp2Add --
+func Fp2Add(out *Fp2, x *Fp2, y *Fp2) {
+	fp2Add(out, x, y)
+}
+
+// Fp2Sub --
+func Fp2Sub(out *Fp2, x *Fp2, y *Fp2) {
+	fp2Sub(out, x, y)
+}
+
+// Fp2Mul --
+func Fp2Mul(out *Fp2, x *Fp2, y *Fp2) {
+	fp2Mul(out, x, y)
+}
+
+// Fp2Div --
+func Fp2Div(out *Fp2, x *Fp2, y *Fp2) {
+	var t Fp2
+
+	fp2Inv(&t, y)
+	fp2Mul(out, x, &t)
+}
+
+// Fp2SquareRoot --
+func Fp2SquareRoot(out *Fp2, x *Fp2) bool {
+	return fp2Sqrt(out, x)
+}
+
+// G1 --
+type G1 struct {
+	X Fp
+	Y Fp
+	Z Fp
+}
+
+// Clear --
+func (x *G1) Clear() {
+	*x = G1{}
+}
+
+// SetString -- "0" for the identity or "1 x y" for the affine point
+func (x *G1) SetString(s string, base int) error {
+	var t G1
+
+	fields := strings.Fields(s)
+
+	switch {
+	case len(fields) == 1 && fields[0] == "0":
+	case len(fields) == 3 && fields[0] == "1":
+		if !fpField.setString(&t.X.v, fields[1], base) || !fpField.setString(&t.Y.v, fields[2], base) {
+			return fmt.Errorf("err G1.SetString %s", s)
+		}
+
+		t.Z.v = fpField.one
+
+		if !t.IsValid() {
+			return fmt.Errorf("err G1.SetString invalid point %s", s)
+		}
+	default:
+		return fmt.Errorf("err G1.SetString %s", s)
+	}
+
+	*x = t
+
+	return nil
+}
+
+// Deserialize --
+func (x *G1) Deserialize(buf []byte) error {
+	if len(buf) != fpByteSize {
+		return fmt.Errorf("err G1.Deserialize %x", buf)
+	}
+
+	if isZeroBytes(buf) {
+		x.Clear()
+
+		return nil
+	}
+
+	var (
+		t      G1
+		xBuf   = append([]byte{}, buf...)
+		yIsOdd = xBuf[fpByteSize-1]&0x80 != 0
+	)
+
+	xBuf[fpByteSize-1] &= 0x7f
+
+	if !fpField.deserialize(&t.X.v, xBuf) {
+		return fmt.Errorf("err G1.Deserialize %x", buf)
+	}
+
+	g1Weierstrass(&t.Y.v, &t.X.v)
+
+	if !fpField.sqrt(&t.Y.v, &t.Y.v) {
+		return fmt.Errorf("err G1.Deserialize %x", buf)
+	}
+
+	if fpField.isOdd(&t.Y.v) != yIsOdd {
+		fpField.neg(&t.Y.v, &t.Y.v)
+	}
+
+	t.Z.v = fpField.one
+
+	if verifyOrderG1Flag && !g1IsValidOrder(&t) {
+		return fmt.Errorf("err G1.Deserialize %x", buf)
+	}
+
+	*x = t
+
+	return nil
+}
+
+// DeserializeUncompressed -- x.Deserialize() + y.Deserialize()
+func (x *G1) DeserializeUncompressed(buf []byte) error {
+	if isZeroFormat(buf, GetG1ByteSize()*2) {
+		x.Clear()
+		return nil
+	}
+
+	if len(buf) < 2*fpByteSize {
+		return fmt.Errorf("err UncompressedDeserialize %x", buf)
+	}
+
+	var t G1
+
+	if err := t.X.Deserialize(buf[:fpByteSize]); err != nil {
+		return fmt.Errorf("err UncompressedDeserialize X %x", buf)
+	}
+
+	if err := t.Y.Deserialize(buf[fpByteSize : 2*fpByteSize]); err != nil {
+		return fmt.Errorf("err UncompressedDeserialize Y %x", buf)
+	}
+
+	t.Z.SetInt64(1)
+
+	if !t.IsValid() {
+		return fmt.Errorf("err invalid point")
+	}
+
+	*x = t
+
+	return nil
+}
+
+// IsEqual --
+func (x *G1) IsEqual(rhs *G1) bool {
+	return g1IsEqual(x, rhs)
+}
+
+// IsZero --
+func (x *G1) IsZero() bool {
+	return g1IsZero(x)
+}
+
+// IsValid --
+func (x *G1) IsValid() bool {
+	if !g1IsOnCurve(x) {
+		return false
+	}
+
+	return !verifyOrderG1Flag || g1IsValidOrder(x)
+}
+
+// IsValidOrder --
+func (x *G1) IsValidOrder() bool {
+	return g1IsValidOrder(x)
+}
+
+// HashAndMapTo --
+func (x *G1) HashAndMapTo(buf []byte) error {
+	var t Fp
+
+	t.SetHashOf(buf)
+
+	return MapToG1(x, &t)
+}
+
+// GetString --
+func (x *G1) GetString(base int) string {
+	if g1IsZero(x) {
+		return "0"
+	}
+
+	var t G1
+
+	g1Normalize(&t, x)
+
+	return "1 " + t.X.GetString(base) + " " + t.Y.GetString(base)
+}
+
+// Serialize --
+func (x *G1) Serialize() []byte {
+	if g1IsZero(x) {
+		return make([]byte, fpByteSize)
+	}
+
+	var t G1
+
+	g1Normalize(&t, x)
+
+	buf := t.X.Serialize()
+	if t.Y.IsOdd() {
+		buf[fpByteSize-1] |= 0x80
+	}
+
+	return buf
+}
+
+// SerializeUncompressed -- all zero array if x.IsZero()
+func (x *G1) SerializeUncompressed() []byte {
+	if x.IsZero() {
+		buf := make([]byte, GetG1ByteSize()*2)
+		buf[0] = ZERO_HEADER
+		return buf
+	}
+
+	var nx G1
+
+	G1Normalize(&nx, x)
+
+	return append(nx.X.Serialize(), nx.Y.Serialize()...)
+}
+
+// G1Normalize --
+func G1Normalize(out *G1, x *G1) {
+	g1Normalize(out, x)
+}
+
+// G1Neg --
+func G1Neg(out *G1, x *G1) {
+	g1Neg(out, x)
+}
+
+// G1Dbl --
+func G1Dbl(out *G1, x *G1) {
+	g1Dbl(out, x)
+}
+
+// G1Add --
+func G1Add(out *G1, x *G1, y *G1) {
+	g1Add(out, x, y)
+}
+
+// G1Sub --
+func G1Sub(out *G1, x *G1, y *G1) {
+	var t G1
+
+	g1Neg(&t, y)
+	g1Add(out, x, &t)
+}
+
+// G1Mul --
+func G1Mul(out *G1, x *G1, y *Fr) {
+	g1MulBig(out, x, frField.toBig(&y.v))
+}
+
+// G1MulVec -- multi scalar multiplication out = sum mul(xVec[i], yVec[i])
+func G1MulVec(out *G1, xVec []G1, yVec []Fr) {
+	if len(xVec) != len(yVec) {
+		panic("xVec and yVec have the same size")
+	}
+
+	var sum, t G1
+
+	for i := range xVec {
+		G1Mul(&t, &xVec[i], &yVec[i])
+		g1Add(&sum, &sum, &t)
+	}
+
+	*out = sum
+}
+
+// G1MulCT -- the pure Go backend has no constant time multiplication, same as G1Mul
+func G1MulCT(out *G1, x *G1, y *Fr) {
+	G1Mul(out, x, y)
+}
+
+// G2 --
+type G2 struct {
+	X Fp2
+	Y Fp2
+	Z Fp2
+}
+
+// Clear --
+func (x *G2) Clear() {
+	*x = G2{}
+}
+
+// SetString -- "0" for the identity or "1 x.D[0] x.D[1] y.D[0] y.D[1]" for the affine point
+func (x *G2) SetString(s string, base int) error {
+	var t G2
+
+	fields := strings.Fields(s)
+
+	switch {
+	case len(fields) == 1 && fields[0] == "0":
+	case len(fields) == 5 && fields[0] == "1":
+		for i, c := range []*Fp{&t.X.D[0], &t.X.D[1], &t.Y.D[0], &t.Y.D[1]} {
+			if !fpField.setString(&c.v, fields[i+1], base) {
+				return fmt.Errorf("err G2.SetString %s", s)
+			}
+		}
+
+		fp2SetOne(&t.Z)
+
+		if !t.IsValid() {
+			return fmt.Errorf("err G2.SetString invalid point %s", s)
+		}
+	default:
+		return fmt.Errorf("err G2.SetString %s", s)
+	}
+
+	*x = t
+
+	return nil
+}
+
+// Deserialize --
+func (x *G2) Deserialize(buf []byte) error {
+	if len(buf) != 2*fpByteSize {
+		return fmt.Errorf("err G2.Deserialize %x", buf)
+	}
+
+	if isZeroBytes(buf) {
+		x.Clear()
+
+		return nil
+	}
+
+	var (
+		t      G2
+		xBuf   = append([]byte{}, buf...)
+		yIsOdd = xBuf[2*fpByteSize-1]&0x80 != 0
+	)
+
+	xBuf[2*fpByteSize-1] &= 0x7f
+
+	if err := t.X.Deserialize(xBuf); err != nil {
+		return fmt.Errorf("err G2.Deserialize %x", buf)
+	}
+
+	g2Weierstrass(&t.Y, &t.X)
+
+	if !fp2Sqrt(&t.Y, &t.Y) {
+		return fmt.Errorf("err G2.Deserialize %x", buf)
+	}
+
+	if fpField.isOdd(&t.Y.D[0].v) != yIsOdd {
+		fp2Neg(&t.Y, &t.Y)
+	}
+
+	fp2SetOne(&t.Z)
+
+	if verifyOrderG2Flag && !g2IsValidOrder(&t) {
+		return fmt.Errorf("err G2.Deserialize %x", buf)
+	}
+
+	*x = t
+
+	return nil
+}
+
+// DeserializeUncompressed -- x.Deserialize() + y.Deserialize()
+func (x *G2) DeserializeUncompressed(buf []byte) error {
+	if isZeroFormat(buf, GetG2ByteSize()*2) {
+		x.Clear()
+		return nil
+	}
+
+	if len(buf) < 4*fpByteSize {
+		return fmt.Errorf("err UncompressedDeserialize %x", buf)
+	}
+
+	var t G2
+
+	if err := t.X.Deserialize(buf[:2*fpByteSize]); err != nil {
+		return fmt.Errorf("err UncompressedDeserialize X %x", buf)
+	}
+
+	if err := t.Y.Deserialize(buf[2*fpByteSize : 4*fpByteSize]); err != nil {
+		return fmt.Errorf("err UncompressedDeserialize Y %x", buf)
+	}
+
+	fp2SetOne(&t.Z)
+
+	if !t.IsValid() {
+		return fmt.Errorf("err invalid point")
+	}
+
+	*x = t
+
+	return nil
+}
+
+// IsEqual --
+func (x *G2) IsEqual(rhs *G2) bool {
+	return g2IsEqual(x, rhs)
+}
+
+// IsZero --
+func (x *G2) IsZero() bool {
+	return g2IsZero(x)
+}
+
+// IsValid --
+func (x *G2) IsValid() bool {
+	if !g2IsOnCurve(x) {
+		return false
+	}
+
+	return !verifyOrderG2Flag || g2IsValidOrder(x)
+}
+
+// IsValidOrder --
+func (x *G2) IsValidOrder() bool {
+	return g2IsValidOrder(x)
+}
+
+// HashAndMapTo --
+func (x *G2) HashAndMapTo(buf []byte) error {
+	var t Fp2
+
+	t.D[0].SetHashOf(buf)
+
+	return MapToG2(x, &t)
+}
+
+// GetString --
+func (x *G2) GetString(base int) string {
+	if g2IsZero(x) {
+		return "0"
+	}
+
+	var t G2
+
+	g2Normalize(&t, x)
+
+	return strings.Join([]string{
+		"1",
+		t.X.D[0].GetString(base), t.X.D[1].GetString(base),
+		t.Y.D[0].GetString(base), t.Y.D[1].GetString(base),
+	}, " ")
+}
+
+// Serialize --
+func (x *G2) Serialize() []byte {
+	if g2IsZero(x) {
+		return make([]byte, 2*fpByteSize)
+	}
+
+	var t G2
+
+	g2Normalize(&t, x)
+
+	buf := t.X.Serialize()
+	if t.Y.D[0].IsOdd() {
+		buf[2*fpByteSize-1] |= 0x80
+	}
+
+	return buf
+}
+
+// SerializeUncompressed -- all zero array if x.IsZero()
+func (x *G2) SerializeUncompressed() []byte {
+	if x.IsZero() {
+		buf := make([]byte, GetG2ByteSize()*2)
+		buf[0] = ZERO_HEADER
+		return buf
+	}
+
+	var nx G2
+
+	G2Normalize(&nx, x)
+
+	return append(nx.X.Serialize(), nx.Y.Serialize()...)
+}
+
+// G2Normalize --
+func G2Normalize(out *G2, x *G2) {
+	g2Normalize(out, x)
+}
+
+// G2Neg --
+func G2Neg(out *G2, x *G2) {
+	g2Neg(out, x)
+}
+
+// G2Dbl --
+func G2Dbl(out *G2, x *G2) {
+	g2Dbl(out, x)
+}
+
+// G2Add --
+func G2Add(out *G2, x *G2, y *G2) {
+	g2Add(out, x, y)
+}
+
+// G2Sub --
+func G2Sub(out *G2, x *G2, y *G2) {
+	var t G2
+
+	g2Neg(&t, y)
+	g2Add(out, x, &t)
+}
+
+// G2Mul --
+func G2Mul(out *G2, x *G2, y *Fr) {
+	g2MulBig(out, x, frField.toBig(&y.v))
+}
+
+// G2MulVec -- multi scalar multiplication out = sum mul(xVec[i], yVec[i])
+func G2MulVec(out *G2, xVec []G2, yVec []Fr) {
+	if len(xVec) != len(yVec) {
+		panic("xVec and yVec have the same size")
+	}
+
+	var sum, t G2
+
+	for i := range xVec {
+		G2Mul(&t, &xVec[i], &yVec[i])
+		g2Add(&sum, &sum, &t)
+	}
+
+	*out = sum
+}
+
+// GT --
+type GT struct {
+	v fp12
+}
+
+// coefficients returns the Fp coefficients of x in the order of the mcl serialization
+func (x *GT) coefficients() []*Fp {
+	coefficients := make([]*Fp, 0, 12)
+
+	for i := range x.v.c {
+		for j := range x.v.c[i].b {
+			coefficients = append(coefficients, &x.v.c[i].b[j].D[0], &x.v.c[i].b[j].D[1])
+		}
+	}
+
+	return coefficients
+}
+
+// Clear --
+func (x *GT) Clear() {
+	*x = GT{}
+}
+
+// SetInt64 --
+func (x *GT) SetInt64(v int64) {
+	x.Clear()
+	x.v.c[0].b[0].D[0].SetInt64(v)
+}
+
+// SetString --
+func (x *GT) SetString(s string, base int) error {
+	var t GT
+
+	fields := strings.Fields(s)
+	coefficients := t.coefficients()
+
+	if len(fields) != len(coefficients) {
+		return fmt.Errorf("err GT.SetString %s", s)
+	}
+
+	for i, c := range coefficients {
+		if !fpField.setString(&c.v, fields[i], base) {
+			return fmt.Errorf("err GT.SetString %s", s)
+		}
+	}
+
+	*x = t
+
+	return nil
+}
+
+// Deserialize --
+func (x *GT) Deserialize(buf []byte) error {
+	var t GT
+
+	coefficients := t.coefficients()
+
+	if len(buf) != len(coefficients)*fpByteSize {
+		return fmt.Errorf("err GT.Deserialize %x", buf)
+	}
+
+	for i, c := range coefficients {
+		if !fpField.deserialize(&c.v, buf[i*fpByteSize:(i+1)*fpByteSize]) {
+			return fmt.Errorf("err GT.Deserialize %x", buf)
+		}
+	}
+
+	*x = t
+
+	return nil
+}
+
+// IsEqual --
+func (x *GT) IsEqual(rhs *GT) bool {
+	return x.v == rhs.v
+}
+
+// IsZero --
+func (x *GT) IsZero() bool {
+	return fp12IsZero(&x.v)
+}
+
+// IsOne --
+func (x *GT) IsOne() bool {
+	return fp12IsOne(&x.v)
+}
+
+// GetString --
+func (x *GT) GetString(base int) string {
+	coefficients := x.coefficients()
+	fields := make([]string, len(coefficients))
+
+	for i, c := range coefficients {
+		fields[i] = c.GetString(base)
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// Serialize --
+func (x *GT) Serialize() []byte {
+	coefficients := x.coefficients()
+	buf := make([]byte, 0, len(coefficients)*fpByteSize)
+
+	for _, c := range coefficients {
+		buf = append(buf, c.Serialize()...)
+	}
+
+	return buf
+}
+
+// GTNeg --
+func GTNeg(out *GT, x *GT) {
+	fp12Neg(&out.v, &x.v)
+}
+
+// GTInv -- the unitary inverse, which is the inverse for the elements of GT
+func GTInv(out *GT, x *GT) {
+	fp12Conj(&out.v, &x.v)
+}
+
+// GTAdd --
+func GTAdd(out *GT, x *GT, y *GT) {
+	fp12Add(&out.v, &x.v, &y.v)
+}
+
+// GTSub --
+func GTSub(out *GT, x *GT, y *GT) {
+	fp12Sub(&out.v, &x.v, &y.v)
+}
+
+// GTMul --
+func GTMul(out *GT, x *GT, y *GT) {
+	fp12Mul(&out.v, &x.v, &y.v)
+}
+
+// GTDiv --
+func GTDiv(out *GT, x *GT, y *GT) {
+	var t fp12
+
+	fp12Inv(&t, &y.v)
+	fp12Mul(&out.v, &x.v, &t)
+}
+
+// GTPow --
+func GTPow(out *GT, x *GT, y *Fr) {
+	fp12Exp(&out.v, &x.v, frField.toBig(&y.v))
+}
+
+// MapToG1 --
+func MapToG1(out *G1, x *Fp) error {
+	if !mapToG1BN(out, &x.v) {
+		return fmt.Errorf("err MapToG1")
+	}
+
+	return nil
+}
+
+// MapToG2 --
+func MapToG2(out *G2, x *Fp2) error {
+	if !mapToG2BN(out, x) {
+		return fmt.Errorf("err MapToG2")
+	}
+
+	return nil
+}
+
+// Pairing --
+func Pairing(out *GT, x *G1, y *G2) {
+	MillerLoop(out, x, y)
+	FinalExp(out, out)
+}
+
+// FinalExp --
+func FinalExp(out *GT, x *GT) {
+	finalExp(&out.v, &x.v)
+}
+
+// MillerLoop --
+func MillerLoop(out *GT, x *G1, y *G2) {
+	millerLoop(&out.v, x, millerLines(y))
+}
+
+// MillerLoopVec -- multi pairings ; out = prod_i e(xVec[i], yVec[i])
+func MillerLoopVec(out *GT, xVec []G1, yVec []G2) {
+	n := len(xVec)
+	if n != len(yVec) {
+		panic("xVec and yVec have the same size")
+	}
+
+	var f, t fp12
+
+	fp12SetOne(&f)
+
+	for i := 0; i < n; i++ {
+		millerLoop(&t, &xVec[i], millerLines(&yVec[i]))
+		fp12Mul(&f, &f, &t)
+	}
+
+	out.v = f
+}
+
+// GetUint64NumToPrecompute --
+func GetUint64NumToPrecompute() int {
+	return lineNum * lineUint64Num
+}
+
+// PrecomputeG2 --
+func PrecomputeG2(Q *G2) []uint64 {
+	return encodeLines(millerLines(Q))
+}
+
+// PrecomputedMillerLoop --
+func PrecomputedMillerLoop(out *GT, P *G1, Qbuf []uint64) {
+	millerLoop(&out.v, P, decodeLines(Qbuf))
+}
+
+// PrecomputedMillerLoop2 --
+func PrecomputedMillerLoop2(out *GT, P1 *G1, Q1buf []uint64, P2 *G1, Q2buf []uint64) {
+	var t fp12
+
+	millerLoop(&out.v, P1, decodeLines(Q1buf))
+	millerLoop(&t, P2, decodeLines(Q2buf))
+	fp12Mul(&out.v, &out.v, &t)
+}
+
+// FrEvaluatePolynomial -- y = c[0] + c[1] * x + c[2] * x^2 + ...
+func FrEvaluatePolynomial(y *Fr, c []Fr, x *Fr) error {
+	var t Fr
+
+	for i := len(c) - 1; i >= 0; i-- {
+		FrMul(&t, &t, x)
+		FrAdd(&t, &t, &c[i])
+	}
+
+	*y = t
+
+	return nil
+}
+
+// G1EvaluatePolynomial -- y = c[0] + c[1] * x + c[2] * x^2 + ...
+func G1EvaluatePolynomial(y *G1, c []G1, x *Fr) error {
+	var t G1
+
+	for i := len(c) - 1; i >= 0; i-- {
+		G1Mul(&t, &t, x)
+		G1Add(&t, &t, &c[i])
+	}
+
+	*y = t
+
+	return nil
+}
+
+// G2EvaluatePolynomial -- y = c[0] + c[1] * x + c[2] * x^2 + ...
+func G2EvaluatePolynomial(y *G2, c []G2, x *Fr) error {
+	var t G2
+
+	for i := len(c) - 1; i >= 0; i-- {
+		G2Mul(&t, &t, x)
+		G2Add(&t, &t, &c[i])
+	}
+
+	*y = t
+
+	return nil
+}
+
+// lagrangeCoefficients returns the Lagrange coefficients at zero for the points xVec,
+// which must be distinct and non-zero
+func lagrangeCoefficients(xVec []Fr) ([]Fr, error) {
+	var prod Fr
+
+	prod.SetInt64(1)
+
+	for i := range xVec {
+		if xVec[i].IsZero() {
+			return nil, fmt.Errorf("zero x")
+		}
+
+		FrMul(&prod, &prod, &xVec[i])
+	}
+
+	coefficients := make([]Fr, len(xVec))
+
+	for i := range xVec {
+		var den, t Fr
+
+		den = xVec[i]
+
+		for j := range xVec {
+			if i == j {
+				continue
+			}
+
+			FrSub(&t, &xVec[j], &xVec[i])
+
+			if t.IsZero() {
+				return nil, fmt.Errorf("duplicate x")
+			}
+
+			FrMul(&den, &den, &t)
+		}
+
+		FrDiv(&coefficients[i], &prod, &den)
+	}
+
+	return coefficients, nil
+}
+
+// FrLagrangeInterpolation --
+func FrLagrangeInterpolation(out *Fr, xVec []Fr, yVec []Fr) error {
+	n := len(xVec)
+	if n == 0 {
+		return fmt.Errorf("err FrLagrangeInterpolation:n=0")
+	}
+	if n != len(yVec) {
+		return fmt.Errorf("err FrLagrangeInterpolation:bad size")
+	}
+
+	coefficients, err := lagrangeCoefficients(xVec)
+	if err != nil {
+		return fmt.Errorf("err FrLagrangeInterpolation")
+	}
+
+	var sum, t Fr
+
+	for i := range yVec {
+		FrMul(&t, &yVec[i], &coefficients[i])
+		FrAdd(&sum, &sum, &t)
+	}
+
+	*out = sum
+
+	return nil
+}
+
+// G1LagrangeInterpolation --
+func G1LagrangeInterpolation(out *G1, xVec []Fr, yVec []G1) error {
+	n := len(xVec)
+	if n == 0 {
+		return fmt.Errorf("err G1LagrangeInterpolation:n=0")
+	}
+	if n != len(yVec) {
+		return fmt.Errorf("err G1LagrangeInterpolation:bad size")
+	}
+
+	coefficients, err := lagrangeCoefficients(xVec)
+	if err != nil {
+		return fmt.Errorf("err G1LagrangeInterpolation")
+	}
+
+	G1MulVec(out, yVec, coefficients)
+
+	return nil
+}
+
+// G2LagrangeInterpolation --
+func G2LagrangeInterpolation(out *G2, xVec []Fr, yVec []G2) error {
+	n := len(xVec)
+	if n == 0 {
+		return fmt.Errorf("err G2LagrangeInterpolation:n=0")
+	}
+	if n != len(yVec) {
+		return fmt.Errorf("err G2LagrangeInterpolation:bad size")
+	}
+
+	coefficients, err := lagrangeCoefficients(xVec)
+	if err != nil {
+		return fmt.Errorf("err G2LagrangeInterpolation")
+	}
+
+	G2MulVec(out, yVec, coefficients)
+
+	return nil
+}
+
+func reverseBytes(buf []byte) []byte {
+	out := make([]byte, len(buf))
+
+	for i, b := range buf {
+		out[len(buf)-1-i] = b
+	}
+
+	return out
+}
@@ -0,0 +1,618 @@
+//go:build !cgo || purego
+
+package core
+
+import (
+	"math/big"
+)
+
+// Points are stored in Jacobian coordinates (X / Z^2, Y / Z^3) like in mcl, the identity has Z = 0.
+// G1 is y^2 = x^3 + 3 over Fp and G2 is the twist y^2 = x^3 + 3 / xi over Fp2
+
+var (
+	curveB   = newFe(fpField, 3)
+	twistB   = newTwistB()
+	bnParamZ = big.NewInt(4965661367192848881)
+	// mapToC1 = sqrt(-3), mapToC2 = (sqrt(-3) - 1) / 2
+	mapToC1, mapToC2 = newMapToConstants()
+)
+
+func newFe(f *field, v int64) fe {
+	var z fe
+
+	f.setInt64(&z, v)
+
+	return z
+}
+
+// newTwistB returns b / xi
+func newTwistB() Fp2 {
+	var z Fp2
+
+	fp2Inv(&z, &xi)
+	fp2MulFp(&z, &z, &curveB)
+
+	return z
+}
+
+func newMapToConstants() (fe, fe) {
+	var c1, c2, half fe
+
+	minus3 := newFe(fpField, -3)
+	fpField.sqrt(&c1, &minus3)
+
+	half = newFe(fpField, 2)
+	fpField.inverse(&half, &half)
+
+	fpField.sub(&c2, &c1, &fpField.one)
+	fpField.mul(&c2, &c2, &half)
+
+	return c1, c2
+}
+
+func g1IsZero(x *G1) bool {
+	return fpField.isZero(&x.Z.v)
+}
+
+func g1Neg(z, x *G1) {
+	z.X = x.X
+	fpField.neg(&z.Y.v, &x.Y.v)
+	z.Z = x.Z
+}
+
+func g1Normalize(z, x *G1) {
+	if g1IsZero(x) {
+		*z = *x
+
+		return
+	}
+
+	var zInv, zInv2 fe
+
+	fpField.inverse(&zInv, &x.Z.v)
+	fpField.sqr(&zInv2, &zInv)
+	fpField.mul(&z.X.v, &x.X.v, &zInv2)
+	fpField.mul(&zInv2, &zInv2, &zInv)
+	fpField.mul(&z.Y.v, &x.Y.v, &zInv2)
+	z.Z.v = fpField.one
+}
+
+// g1Dbl uses dbl-2009-l
+func g1Dbl(z, x *G1) {
+	if g1IsZero(x) || fpField.isZero(&x.Y.v) {
+		*z = G1{}
+
+		return
+	}
+
+	var a, b, c, d, e, f, t fe
+
+	fpField.sqr(&a, &x.X.v)
+	fpField.sqr(&b, &x.Y.v)
+	fpField.sqr(&c, &b)
+
+	fpField.add(&d, &x.X.v, &b)
+	fpField.sqr(&d, &d)
+	fpField.sub(&d, &d, &a)
+	fpField.sub(&d, &d, &c)
+	fpField.add(&d, &d, &d)
+
+	fpField.add(&e, &a, &a)
+	fpField.add(&e, &e, &a)
+	fpField.sqr(&f, &e)
+
+	// Z3 = 2 Y1 Z1
+	fpField.mul(&t, &x.Y.v, &x.Z.v)
+	fpField.add(&z.Z.v, &t, &t)
+
+	// X3 = F - 2D
+	fpField.sub(&z.X.v, &f, &d)
+	fpField.sub(&z.X.v, &z.X.v, &d)
+
+	// Y3 = E (D - X3) - 8C
+	fpField.sub(&t, &d, &z.X.v)
+	fpField.mul(&t, &e, &t)
+	fpField.add(&c, &c, &c)
+	fpField.add(&c, &c, &c)
+	fpField.add(&c, &c, &c)
+	fpField.sub(&z.Y.v, &t, &c)
+}
+
+// g1Add uses add-2007-bl
+func g1Add(z, x, y *G1) {
+	if g1IsZero(x) {
+		*z = *y
+
+		return
+	}
+
+	if g1IsZero(y) {
+		*z = *x
+
+		return
+	}
+
+	var z1z1, z2z2, u1, u2, s1, s2, h, i, j, r, v, t fe
+
+	fpField.sqr(&z1z1, &x.Z.v)
+	fpField.sqr(&z2z2, &y.Z.v)
+	fpField.mul(&u1, &x.X.v, &z2z2)
+	fpField.mul(&u2, &y.X.v, &z1z1)
+	fpField.mul(&s1, &x.Y.v, &y.Z.v)
+	fpField.mul(&s1, &s1, &z2z2)
+	fpField.mul(&s2, &y.Y.v, &x.Z.v)
+	fpField.mul(&s2, &s2, &z1z1)
+
+	fpField.sub(&h, &u2, &u1)
+	fpField.sub(&r, &s2, &s1)
+
+	if fpField.isZero(&h) {
+		if fpField.isZero(&r) {
+			g1Dbl(z, x)
+		} else {
+			*z = G1{}
+		}
+
+		return
+	}
+
+	fpField.add(&r, &r, &r)
+	fpField.add(&i, &h, &h)
+	fpField.sqr(&i, &i)
+	fpField.mul(&j, &h, &i)
+	fpField.mul(&v, &u1, &i)
+
+	// Z3 = ((Z1 + Z2)^2 - Z1Z1 - Z2Z2) H
+	fpField.add(&t, &x.Z.v, &y.Z.v)
+	fpField.sqr(&t, &t)
+	fpField.sub(&t, &t, &z1z1)
+	fpField.sub(&t, &t, &z2z2)
+	fpField.mul(&z.Z.v, &t, &h)
+
+	// X3 = r^2 - J - 2V
+	fpField.sqr(&t, &r)
+	fpField.sub(&t, &t, &j)
+	fpField.sub(&t, &t, &v)
+	fpField.sub(&z.X.v, &t, &v)
+
+	// Y3 = r (V - X3) - 2 S1 J
+	fpField.sub(&t, &v, &z.X.v)
+	fpField.mul(&t, &t, &r)
+	fpField.mul(&s1, &s1, &j)
+	fpField.add(&s1, &s1, &s1)
+	fpField.sub(&z.Y.v, &t, &s1)
+}
+
+func g1IsEqual(x, y *G1) bool {
+	if g1IsZero(x) || g1IsZero(y) {
+		return g1IsZero(x) && g1IsZero(y)
+	}
+
+	var z1z1, z2z2, a, b fe
+
+	fpField.sqr(&z1z1, &x.Z.v)
+	fpField.sqr(&z2z2, &y.Z.v)
+	fpField.mul(&a, &x.X.v, &z2z2)
+	fpField.mul(&b, &y.X.v, &z1z1)
+
+	if a != b {
+		return false
+	}
+
+	fpField.mul(&z1z1, &z1z1, &x.Z.v)
+	fpField.mul(&z2z2, &z2z2, &y.Z.v)
+	fpField.mul(&a, &x.Y.v, &z2z2)
+	fpField.mul(&b, &y.Y.v, &z1z1)
+
+	return a == b
+}
+
+// g1IsOnCurve checks Y^2 = X^3 + b Z^6
+func g1IsOnCurve(x *G1) bool {
+	if g1IsZero(x) {
+		return true
+	}
+
+	var y2, x3, z6 fe
+
+	fpField.sqr(&y2, &x.Y.v)
+	fpField.sqr(&x3, &x.X.v)
+	fpField.mul(&x3, &x3, &x.X.v)
+	fpField.sqr(&z6, &x.Z.v)
+	fpField.mul(&z6, &z6, &x.Z.v)
+	fpField.sqr(&z6, &z6)
+	fpField.mul(&z6, &z6, &curveB)
+	fpField.add(&x3, &x3, &z6)
+
+	return y2 == x3
+}
+
+func g1MulBig(z, x *G1, k *big.Int) {
+	var r G1
+
+	base := *x
+
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		g1Dbl(&r, &r)
+
+		if k.Bit(i) == 1 {
+			g1Add(&r, &r, &base)
+		}
+	}
+
+	if k.Sign() < 0 {
+		g1Neg(&r, &r)
+	}
+
+	*z = r
+}
+
+func g1IsValidOrder(x *G1) bool {
+	var t G1
+
+	g1MulBig(&t, x, frField.modulusBig)
+
+	return g1IsZero(&t)
+}
+
+// g1Weierstrass computes x^3 + b
+func g1Weierstrass(y, x *fe) {
+	fpField.sqr(y, x)
+	fpField.mul(y, y, x)
+	fpField.add(y, y, &curveB)
+}
+
+func g2IsZero(x *G2) bool {
+	return fp2IsZero(&x.Z)
+}
+
+func g2Neg(z, x *G2) {
+	z.X = x.X
+	fp2Neg(&z.Y, &x.Y)
+	z.Z = x.Z
+}
+
+func g2Normalize(z, x *G2) {
+	if g2IsZero(x) {
+		*z = *x
+
+		return
+	}
+
+	var zInv, zInv2 Fp2
+
+	fp2Inv(&zInv, &x.Z)
+	fp2Sqr(&zInv2, &zInv)
+	fp2Mul(&z.X, &x.X, &zInv2)
+	fp2Mul(&zInv2, &zInv2, &zInv)
+	fp2Mul(&z.Y, &x.Y, &zInv2)
+	fp2SetOne(&z.Z)
+}
+
+// g2Dbl uses dbl-2009-l
+func g2Dbl(z, x *G2) {
+	if g2IsZero(x) || fp2IsZero(&x.Y) {
+		*z = G2{}
+
+		return
+	}
+
+	var a, b, c, d, e, f, t Fp2
+
+	fp2Sqr(&a, &x.X)
+	fp2Sqr(&b, &x.Y)
+	fp2Sqr(&c, &b)
+
+	fp2Add(&d, &x.X, &b)
+	fp2Sqr(&d, &d)
+	fp2Sub(&d, &d, &a)
+	fp2Sub(&d, &d, &c)
+	fp2Add(&d, &d, &d)
+
+	fp2Add(&e, &a, &a)
+	fp2Add(&e, &e, &a)
+	fp2Sqr(&f, &e)
+
+	fp2Mul(&t, &x.Y, &x.Z)
+	fp2Add(&z.Z, &t, &t)
+
+	fp2Sub(&z.X, &f, &d)
+	fp2Sub(&z.X, &z.X, &d)
+
+	fp2Sub(&t, &d, &z.X)
+	fp2Mul(&t, &e, &t)
+	fp2Add(&c, &c, &c)
+	fp2Add(&c, &c, &c)
+	fp2Add(&c, &c, &c)
+	fp2Sub(&z.Y, &t, &c)
+}
+
+// g2Add uses add-2007-bl
+func g2Add(z, x, y *G2) {
+	if g2IsZero(x) {
+		*z = *y
+
+		return
+	}
+
+	if g2IsZero(y) {
+		*z = *x
+
+		return
+	}
+
+	var z1z1, z2z2, u1, u2, s1, s2, h, i, j, r, v, t Fp2
+
+	fp2Sqr(&z1z1, &x.Z)
+	fp2Sqr(&z2z2, &y.Z)
+	fp2Mul(&u1, &x.X, &z2z2)
+	fp2Mul(&u2, &y.X, &z1z1)
+	fp2Mul(&s1, &x.Y, &y.Z)
+	fp2Mul(&s1, &s1, &z2z2)
+	fp2Mul(&s2, &y.Y, &x.Z)
+	fp2Mul(&s2, &s2, &z1z1)
+
+	fp2Sub(&h, &u2, &u1)
+	fp2Sub(&r, &s2, &s1)
+
+	if fp2IsZero(&h) {
+		if fp2IsZero(&r) {
+			g2Dbl(z, x)
+		} else {
+			*z = G2{}
+		}
+
+		return
+	}
+
+	fp2Add(&r, &r, &r)
+	fp2Add(&i, &h, &h)
+	fp2Sqr(&i, &i)
+	fp2Mul(&j, &h, &i)
+	fp2Mul(&v, &u1, &i)
+
+	fp2Add(&t, &x.Z, &y.Z)
+	fp2Sqr(&t, &t)
+	fp2Sub(&t, &t, &z1z1)
+	fp2Sub(&t, &t, &z2z2)
+	fp2Mul(&z.Z, &t, &h)
+
+	fp2Sqr(&t, &r)
+	fp2Sub(&t, &t, &j)
+	fp2Sub(&t, &t, &v)
+	fp2Sub(&z.X, &t, &v)
+
+	fp2Sub(&t, &v, &z.X)
+	fp2Mul(&t, &t, &r)
+	fp2Mul(&s1, &s1, &j)
+	fp2Add(&s1, &s1, &s1)
+	fp2Sub(&z.Y, &t, &s1)
+}
+
+func g2IsEqual(x, y *G2) bool {
+	if g2IsZero(x) || g2IsZero(y) {
+		return g2IsZero(x) && g2IsZero(y)
+	}
+
+	var z1z1, z2z2, a, b Fp2
+
+	fp2Sqr(&z1z1, &x.Z)
+	fp2Sqr(&z2z2, &y.Z)
+	fp2Mul(&a, &x.X, &z2z2)
+	fp2Mul(&b, &y.X, &z1z1)
+
+	if a != b {
+		return false
+	}
+
+	fp2Mul(&z1z1, &z1z1, &x.Z)
+	fp2Mul(&z2z2, &z2z2, &y.Z)
+	fp2Mul(&a, &x.Y, &z2z2)
+	fp2Mul(&b, &y.Y, &z1z1)
+
+	return a == b
+}
+
+func g2IsOnCurve(x *G2) bool {
+	if g2IsZero(x) {
+		return true
+	}
+
+	var y2, x3, z6 Fp2
+
+	fp2Sqr(&y2, &x.Y)
+	fp2Sqr(&x3, &x.X)
+	fp2Mul(&x3, &x3, &x.X)
+	fp2Sqr(&z6, &x.Z)
+	fp2Mul(&z6, &z6, &x.Z)
+	fp2Sqr(&z6, &z6)
+	fp2Mul(&z6, &z6, &twistB)
+	fp2Add(&x3, &x3, &z6)
+
+	return y2 == x3
+}
+
+func g2MulBig(z, x *G2, k *big.Int) {
+	var r G2
+
+	base := *x
+
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		g2Dbl(&r, &r)
+
+		if k.Bit(i) == 1 {
+			g2Add(&r, &r, &base)
+		}
+	}
+
+	if k.Sign() < 0 {
+		g2Neg(&r, &r)
+	}
+
+	*z = r
+}
+
+func g2IsValidOrder(x *G2) bool {
+	var t G2
+
+	g2MulBig(&t, x, frField.modulusBig)
+
+	return g2IsZero(&t)
+}
+
+func g2Weierstrass(y, x *Fp2) {
+	fp2Sqr(y, x)
+	fp2Mul(y, y, x)
+	fp2Add(y, y, &twistB)
+}
+
+// g2Frobenius maps the point to the twist of the image of its untwisted point under the Frobenius endomorphism
+func g2Frobenius(z, x *G2) {
+	if g2IsZero(x) {
+		*z = *x
+
+		return
+	}
+
+	var t G2
+
+	g2Normalize(&t, x)
+	fp2Conj(&z.X, &t.X)
+	fp2Mul(&z.X, &z.X, &twistFrobeniusX)
+	fp2Conj(&z.Y, &t.Y)
+	fp2Mul(&z.Y, &z.Y, &twistFrobeniusY)
+	fp2SetOne(&z.Z)
+}
+
+// g2MulByCofactor computes zQ + Frob(3zQ) + Frob^2(zQ) + Frob^3(Q) as mcl does for BN curves,
+// see "Faster Hashing to G2" by Fuentes-Castaneda, Knapp and Rodriguez-Henriquez, section 6.1
+func g2MulByCofactor(z, x *G2) {
+	var t0, t1, t2 G2
+
+	g2MulBig(&t0, x, bnParamZ)
+	g2Dbl(&t1, &t0)
+	g2Add(&t1, &t1, &t0)
+	g2Frobenius(&t1, &t1)
+	g2Frobenius(&t2, &t0)
+	g2Frobenius(&t2, &t2)
+	g2Add(&t0, &t0, &t1)
+	g2Add(&t0, &t0, &t2)
+	g2Frobenius(&t2, x)
+	g2Frobenius(&t2, &t2)
+	g2Frobenius(&t2, &t2)
+	g2Add(z, &t0, &t2)
+}
+
+// mapToG1BN maps the field element to the curve as mcl does with MCL_MAP_TO_MODE_ORIGINAL,
+// see "Indifferentiable Hashing to Barreto-Naehrig Curves" by Fouque and Tibouchi
+func mapToG1BN(out *G1, t *fe) bool {
+	negative := fpField.legendre(t) < 0
+
+	if fpField.isZero(t) {
+		return false
+	}
+
+	var w, x, y fe
+
+	// w = sqrt(-3) t / (t^2 + b + 1)
+	fpField.sqr(&w, t)
+	fpField.add(&w, &w, &curveB)
+	fpField.add(&w, &w, &fpField.one)
+
+	if fpField.isZero(&w) {
+		return false
+	}
+
+	fpField.inverse(&w, &w)
+	fpField.mul(&w, &w, &mapToC1)
+	fpField.mul(&w, &w, t)
+
+	for i := 0; i < 3; i++ {
+		switch i {
+		case 0:
+			// x = (sqrt(-3) - 1) / 2 - t w
+			fpField.mul(&x, t, &w)
+			fpField.sub(&x, &mapToC2, &x)
+		case 1:
+			// x = -1 - x
+			fpField.neg(&x, &x)
+			fpField.sub(&x, &x, &fpField.one)
+		case 2:
+			// x = 1 + 1 / w^2
+			fpField.sqr(&x, &w)
+			fpField.inverse(&x, &x)
+			fpField.add(&x, &x, &fpField.one)
+		}
+
+		g1Weierstrass(&y, &x)
+
+		if fpField.sqrt(&y, &y) {
+			if negative {
+				fpField.neg(&y, &y)
+			}
+
+			out.X.v, out.Y.v, out.Z.v = x, y, fpField.one
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// mapToG2BN is mapToG1BN over Fp2 followed by the cofactor clearing
+func mapToG2BN(out *G2, t *Fp2) bool {
+	negative := fp2Legendre(t) < 0
+
+	if fp2IsZero(t) {
+		return false
+	}
+
+	var w, x, y Fp2
+
+	fp2Sqr(&w, t)
+	fp2Add(&w, &w, &twistB)
+	fpField.add(&w.D[0].v, &w.D[0].v, &fpField.one)
+
+	if fp2IsZero(&w) {
+		return false
+	}
+
+	fp2Inv(&w, &w)
+	fp2MulFp(&w, &w, &mapToC1)
+	fp2Mul(&w, &w, t)
+
+	for i := 0; i < 3; i++ {
+		switch i {
+		case 0:
+			fp2Mul(&x, t, &w)
+			fp2Neg(&x, &x)
+			fpField.add(&x.D[0].v, &x.D[0].v, &mapToC2)
+		case 1:
+			fp2Neg(&x, &x)
+			fpField.sub(&x.D[0].v, &x.D[0].v, &fpField.one)
+		case 2:
+			fp2Sqr(&x, &w)
+			fp2Inv(&x, &x)
+			fpField.add(&x.D[0].v, &x.D[0].v, &fpField.one)
+		}
+
+		g2Weierstrass(&y, &x)
+
+		if fp2Sqrt(&y, &y) {
+			if negative {
+				fp2Neg(&y, &y)
+			}
+
+			var p G2
+
+			p.X, p.Y = x, y
+			fp2SetOne(&p.Z)
+			g2MulByCofactor(out, &p)
+
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,500 @@
+//go:build !cgo || purego
+
+package core
+
+import (
+	"crypto/rand"
+	"math/big"
+	"math/bits"
+)
+
+// fe is an element of a prime field below 2^256 stored as little-endian limbs in Montgomery form
+// with R = 2^256, which is the same layout mcl uses for mclBnFp and mclBnFr
+type fe [4]uint64
+
+// field holds the precomputed parameters of a prime field
+type field struct {
+	modulus    fe
+	modulusBig *big.Int
+	// inv is -modulus^-1 mod 2^64
+	inv uint64
+	// one is R mod modulus
+	one fe
+	// r2 is R^2 mod modulus
+	r2     fe
+	bitLen int
+
+	// Tonelli-Shanks parameters: modulus - 1 = q * 2^s, z is the smallest quadratic non-residue
+	s       int
+	qHalf   *big.Int
+	zq      fe
+	sqrtExp *big.Int
+}
+
+var (
+	fpField = newField("21888242871839275222246405745257275088696311157297823662689037894645226208583")
+	frField = newField("21888242871839275222246405745257275088548364400416034343698204186575808495617")
+)
+
+func newField(decimal string) *field {
+	m, _ := new(big.Int).SetString(decimal, 10)
+	f := &field{modulusBig: m, bitLen: m.BitLen()}
+
+	f.modulus = feFromBigRaw(m)
+
+	// -m^-1 mod 2^64 by Newton iteration
+	inv := uint64(1)
+	for i := 0; i < 6; i++ {
+		inv *= 2 - f.modulus[0]*inv
+	}
+
+	f.inv = -inv
+
+	r := new(big.Int).Lsh(big.NewInt(1), 256)
+	f.one = feFromBigRaw(new(big.Int).Mod(r, m))
+	f.r2 = feFromBigRaw(new(big.Int).Mod(new(big.Int).Mul(r, r), m))
+
+	pm1 := new(big.Int).Sub(m, big.NewInt(1))
+	q := new(big.Int).Set(pm1)
+
+	for q.Bit(0) == 0 {
+		q.Rsh(q, 1)
+		f.s++
+	}
+
+	f.qHalf = new(big.Int).Rsh(new(big.Int).Add(q, big.NewInt(1)), 1)
+
+	if f.s == 1 {
+		// m = 3 mod 4, sqrt(x) = x^((m + 1) / 4)
+		f.sqrtExp = new(big.Int).Rsh(new(big.Int).Add(m, big.NewInt(1)), 2)
+	} else {
+		for z := int64(2); ; z++ {
+			zBig := big.NewInt(z)
+			if big.Jacobi(zBig, m) == -1 {
+				f.exp(&f.zq, f.fromBig(zBig), q)
+
+				break
+			}
+		}
+	}
+
+	return f
+}
+
+func feFromBigRaw(x *big.Int) fe {
+	var z fe
+
+	words := x.Bits()
+	for i := 0; i < len(words) && i < 4; i++ {
+		z[i] = uint64(words[i])
+	}
+
+	return z
+}
+
+// toBig returns the canonical value of x
+func (f *field) toBig(x *fe) *big.Int {
+	var raw, one fe
+
+	one[0] = 1
+	f.mul(&raw, x, &one)
+
+	b := make([]byte, 32)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 8; j++ {
+			b[31-i*8-j] = byte(raw[i] >> (8 * j))
+		}
+	}
+
+	return new(big.Int).SetBytes(b)
+}
+
+// fromBig converts the value reduced modulo the field modulus to Montgomery form
+func (f *field) fromBig(x *big.Int) *fe {
+	raw := feFromBigRaw(new(big.Int).Mod(x, f.modulusBig))
+	z := new(fe)
+	f.mul(z, &raw, &f.r2)
+
+	return z
+}
+
+// fromRaw converts the raw value below the modulus to Montgomery form
+func (f *field) fromRaw(z, raw *fe) {
+	f.mul(z, raw, &f.r2)
+}
+
+func (f *field) isZero(x *fe) bool {
+	return x[0]|x[1]|x[2]|x[3] == 0
+}
+
+func (f *field) isOne(x *fe) bool {
+	return *x == f.one
+}
+
+func (f *field) add(z, x, y *fe) {
+	var c uint64
+
+	var t fe
+
+	t[0], c = bits.Add64(x[0], y[0], 0)
+	t[1], c = bits.Add64(x[1], y[1], c)
+	t[2], c = bits.Add64(x[2], y[2], c)
+	t[3], _ = bits.Add64(x[3], y[3], c)
+
+	f.reduceOnce(z, &t)
+}
+
+func (f *field) sub(z, x, y *fe) {
+	var b uint64
+
+	var t fe
+
+	t[0], b = bits.Sub64(x[0], y[0], 0)
+	t[1], b = bits.Sub64(x[1], y[1], b)
+	t[2], b = bits.Sub64(x[2], y[2], b)
+	t[3], b = bits.Sub64(x[3], y[3], b)
+
+	if b != 0 {
+		var c uint64
+
+		t[0], c = bits.Add64(t[0], f.modulus[0], 0)
+		t[1], c = bits.Add64(t[1], f.modulus[1], c)
+		t[2], c = bits.Add64(t[2], f.modulus[2], c)
+		t[3], _ = bits.Add64(t[3], f.modulus[3], c)
+	}
+
+	*z = t
+}
+
+func (f *field) neg(z, x *fe) {
+	if f.isZero(x) {
+		*z = fe{}
+
+		return
+	}
+
+	f.sub(z, &f.modulus, x)
+}
+
+// reduceOnce subtracts the modulus if t >= modulus, both moduli are below 2^255 so t never overflows
+func (f *field) reduceOnce(z, t *fe) {
+	var b uint64
+
+	var d fe
+
+	d[0], b = bits.Sub64(t[0], f.modulus[0], 0)
+	d[1], b = bits.Sub64(t[1], f.modulus[1], b)
+	d[2], b = bits.Sub64(t[2], f.modulus[2], b)
+	d[3], b = bits.Sub64(t[3], f.modulus[3], b)
+
+	if b == 0 {
+		*z = d
+	} else {
+		*z = *t
+	}
+}
+
+// mul computes the Montgomery product x * y / R
+func (f *field) mul(z, x, y *fe) {
+	var t [6]uint64
+
+	for i := 0; i < 4; i++ {
+		var c uint64
+
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(x[j], y[i])
+
+			var cc uint64
+
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j], c = lo, hi
+		}
+
+		t[4], t[5] = bits.Add64(t[4], c, 0)
+
+		m := t[0] * f.inv
+		hi, lo := bits.Mul64(m, f.modulus[0])
+		_, cc := bits.Add64(lo, t[0], 0)
+		c = hi + cc
+
+		for j := 1; j < 4; j++ {
+			hi, lo = bits.Mul64(m, f.modulus[j])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j-1], c = lo, hi
+		}
+
+		t[3], cc = bits.Add64(t[4], c, 0)
+		t[4] = t[5] + cc
+	}
+
+	r := fe{t[0], t[1], t[2], t[3]}
+
+	if t[4] != 0 {
+		var b uint64
+
+		r[0], b = bits.Sub64(r[0], f.modulus[0], 0)
+		r[1], b = bits.Sub64(r[1], f.modulus[1], b)
+		r[2], b = bits.Sub64(r[2], f.modulus[2], b)
+		r[3], _ = bits.Sub64(r[3], f.modulus[3], b)
+		*z = r
+
+		return
+	}
+
+	f.reduceOnce(z, &r)
+}
+
+func (f *field) sqr(z, x *fe) {
+	f.mul(z, x, x)
+}
+
+// exp computes x^e for the non-negative exponent
+func (f *field) exp(z, x *fe, e *big.Int) {
+	r := f.one
+	b := *x
+
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		f.sqr(&r, &r)
+
+		if e.Bit(i) == 1 {
+			f.mul(&r, &r, &b)
+		}
+	}
+
+	*z = r
+}
+
+// inv sets z = 1 / x, the inverse of zero is zero
+func (f *field) inverse(z, x *fe) {
+	if f.isZero(x) {
+		*z = fe{}
+
+		return
+	}
+
+	*z = *f.fromBig(new(big.Int).ModInverse(f.toBig(x), f.modulusBig))
+}
+
+func (f *field) div(z, x, y *fe) {
+	var t fe
+
+	f.inverse(&t, y)
+	f.mul(z, x, &t)
+}
+
+// legendre returns 1 for non-zero squares, -1 for non-squares and 0 for zero
+func (f *field) legendre(x *fe) int {
+	return big.Jacobi(f.toBig(x), f.modulusBig)
+}
+
+// sqrt sets z to a square root of x and reports whether it exists. z is untouched if it does not
+func (f *field) sqrt(z, x *fe) bool {
+	if f.isZero(x) {
+		*z = fe{}
+
+		return true
+	}
+
+	if f.legendre(x) != 1 {
+		return false
+	}
+
+	if f.sqrtExp != nil {
+		f.exp(z, x, f.sqrtExp)
+
+		return true
+	}
+
+	// Tonelli-Shanks
+	var (
+		c, t, r, b fe
+		m          = f.s
+	)
+
+	c = f.zq
+	f.exp(&r, x, f.qHalf)
+	f.sqr(&t, &r)
+	f.div(&t, &t, x)
+
+	for !f.isOne(&t) {
+		i := 0
+
+		for tt := t; !f.isOne(&tt); i++ {
+			f.sqr(&tt, &tt)
+		}
+
+		b = c
+		for j := 0; j < m-i-1; j++ {
+			f.sqr(&b, &b)
+		}
+
+		m = i
+		f.sqr(&c, &b)
+		f.mul(&t, &t, &c)
+		f.mul(&r, &r, &b)
+	}
+
+	*z = r
+
+	return true
+}
+
+func (f *field) isOdd(x *fe) bool {
+	return f.toBig(x).Bit(0) == 1
+}
+
+// isNegative reports whether x >= (modulus + 1) / 2
+func (f *field) isNegative(x *fe) bool {
+	half := new(big.Int).Rsh(new(big.Int).Add(f.modulusBig, big.NewInt(1)), 1)
+
+	return f.toBig(x).Cmp(half) >= 0
+}
+
+func (f *field) setInt64(z *fe, v int64) {
+	*z = *f.fromBig(big.NewInt(v))
+}
+
+// setLittleEndianMask sets z to the little-endian buf masked to the bit length of the modulus.
+// If the result is still not below the modulus, it is masked to one bit less
+func (f *field) setLittleEndianMask(z *fe, buf []byte) {
+	if len(buf) > 32 {
+		buf = buf[:32]
+	}
+
+	var raw fe
+
+	for i, b := range buf {
+		raw[i/8] |= uint64(b) << (8 * (i % 8))
+	}
+
+	maskFe(&raw, f.bitLen)
+
+	if !f.isBelowModulus(&raw) {
+		maskFe(&raw, f.bitLen-1)
+	}
+
+	f.fromRaw(z, &raw)
+}
+
+func maskFe(x *fe, bitLen int) {
+	for i := 0; i < 4; i++ {
+		switch {
+		case bitLen >= (i+1)*64:
+		case bitLen <= i*64:
+			x[i] = 0
+		default:
+			x[i] &= (uint64(1) << (bitLen - i*64)) - 1
+		}
+	}
+}
+
+func (f *field) isBelowModulus(raw *fe) bool {
+	for i := 3; i >= 0; i-- {
+		if raw[i] != f.modulus[i] {
+			return raw[i] < f.modulus[i]
+		}
+	}
+
+	return false
+}
+
+// deserialize reads exactly 32 little-endian bytes of a canonical value
+func (f *field) deserialize(z *fe, buf []byte) bool {
+	if len(buf) != 32 {
+		return false
+	}
+
+	var raw fe
+
+	for i, b := range buf {
+		raw[i/8] |= uint64(b) << (8 * (i % 8))
+	}
+
+	if !f.isBelowModulus(&raw) {
+		return false
+	}
+
+	f.fromRaw(z, &raw)
+
+	return true
+}
+
+// serialize writes the canonical value as 32 little-endian bytes
+func (f *field) serialize(x *fe) []byte {
+	var raw, one fe
+
+	one[0] = 1
+	f.mul(&raw, x, &one)
+
+	buf := make([]byte, 32)
+	for i := range buf {
+		buf[i] = byte(raw[i/8] >> (8 * (i % 8)))
+	}
+
+	return buf
+}
+
+// setByCSPRNG sets z to a uniformly random element
+func (f *field) setByCSPRNG(z *fe) bool {
+	buf := make([]byte, 32)
+
+	for {
+		if _, err := rand.Read(buf); err != nil {
+			return false
+		}
+
+		var raw fe
+
+		for i, b := range buf {
+			raw[i/8] |= uint64(b) << (8 * (i % 8))
+		}
+
+		maskFe(&raw, f.bitLen)
+
+		if f.isBelowModulus(&raw) {
+			f.fromRaw(z, &raw)
+
+			return true
+		}
+	}
+}
+
+// setString parses the value in the given base, which must be below the modulus
+func (f *field) setString(z *fe, s string, base int) bool {
+	negative := false
+	if len(s) > 0 && s[0] == '-' {
+		negative, s = true, s[1:]
+	}
+
+	if base == 16 && len(s) > 2 && (s[:2] == "0x" || s[:2] == "0X") {
+		s = s[2:]
+	}
+
+	v, ok := new(big.Int).SetString(s, base)
+	if !ok || v.Sign() < 0 || v.Cmp(f.modulusBig) >= 0 {
+		return false
+	}
+
+	if negative {
+		v.Neg(v)
+	}
+
+	*z = *f.fromBig(v)
+
+	return true
+}
+
+// getString formats the canonical value in the given base
+func (f *field) getString(x *fe, base int) string {
+	if base != 2 && base != 10 && base != 16 {
+		return ""
+	}
+
+	return f.toBig(x).Text(base)
+}
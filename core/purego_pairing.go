@@ -0,0 +1,275 @@
+//go:build !cgo || purego
+
+package core
+
+import (
+	"math/big"
+)
+
+// The pairing is the optimal ate pairing over the loop count 6u + 2. The Miller loop keeps the
+// twist point in affine coordinates, so a line is fully described by its slope and its value at
+// zero, which is also the layout of the precomputed G2 lines.
+// The line through the untwisted points evaluated at P is yP - slope xP w + (slope xT - yT) w^3
+
+const (
+	fpUint64Num   = 4
+	lineUint64Num = 4 * fpUint64Num
+)
+
+var (
+	ateLoopCount = new(big.Int).Add(new(big.Int).Mul(big.NewInt(6), bnParamZ), big.NewInt(2))
+	// finalExpHard is 2u(6u^2 + 3u + 1)(p^4 - p^2 + 1) / r. The multiple of the hard part comes from
+	// the final exponentiation of Fuentes-Castaneda et al. used by mcl, so GT values match it as well
+	finalExpHard = newFinalExpHard()
+	lineNum      = newLineNum()
+)
+
+func newFinalExpHard() *big.Int {
+	p := fpField.modulusBig
+	p2 := new(big.Int).Mul(p, p)
+	p4 := new(big.Int).Mul(p2, p2)
+
+	e := new(big.Int).Sub(p4, p2)
+	e.Add(e, big.NewInt(1))
+	e.Div(e, frField.modulusBig)
+
+	u := bnParamZ
+	m := new(big.Int).Mul(u, u)
+	m.Mul(m, big.NewInt(6))
+	m.Add(m, new(big.Int).Mul(u, big.NewInt(3)))
+	m.Add(m, big.NewInt(1))
+	m.Mul(m, u)
+	m.Lsh(m, 1)
+
+	return e.Mul(e, m)
+}
+
+// newLineNum counts one doubling per bit below the top one of the loop count, one addition
+// per set bit below the top one and two additions with the Frobenius images of Q
+func newLineNum() int {
+	n := ateLoopCount.BitLen() - 1
+
+	for i := ateLoopCount.BitLen() - 2; i >= 0; i-- {
+		n += int(ateLoopCount.Bit(i))
+	}
+
+	return n + 2
+}
+
+// line is the slope and the value at zero of the line in the twist coordinates.
+// Both are zero if the line is vertical, which evaluates to yP and vanishes after the final exponentiation
+type line struct {
+	slope Fp2
+	c     Fp2
+}
+
+// affineG2 is the running point of the Miller loop, the identity is represented by inf
+type affineG2 struct {
+	x, y Fp2
+	inf  bool
+}
+
+// lineDbl doubles t and returns the tangent line at t
+func lineDbl(t *affineG2) line {
+	var l line
+
+	if t.inf || fp2IsZero(&t.y) {
+		t.inf = true
+
+		return l
+	}
+
+	var num, den, x3 Fp2
+
+	// slope = 3x^2 / 2y
+	fp2Sqr(&num, &t.x)
+	fp2Add(&den, &num, &num)
+	fp2Add(&num, &den, &num)
+	fp2Add(&den, &t.y, &t.y)
+	fp2Inv(&den, &den)
+	fp2Mul(&l.slope, &num, &den)
+
+	// c = slope x - y
+	fp2Mul(&l.c, &l.slope, &t.x)
+	fp2Sub(&l.c, &l.c, &t.y)
+
+	// x3 = slope^2 - 2x, y3 = slope (x - x3) - y = c - slope x3
+	fp2Sqr(&x3, &l.slope)
+	fp2Sub(&x3, &x3, &t.x)
+	fp2Sub(&x3, &x3, &t.x)
+	fp2Mul(&t.y, &l.slope, &x3)
+	fp2Sub(&t.y, &l.c, &t.y)
+	t.x = x3
+
+	return l
+}
+
+// lineAdd adds q to t and returns the line through them
+func lineAdd(t, q *affineG2) line {
+	var l line
+
+	if q.inf {
+		return l
+	}
+
+	if t.inf {
+		*t = *q
+
+		return l
+	}
+
+	var num, den, x3 Fp2
+
+	fp2Sub(&den, &q.x, &t.x)
+
+	if fp2IsZero(&den) {
+		if t.y == q.y {
+			return lineDbl(t)
+		}
+
+		t.inf = true
+
+		return l
+	}
+
+	// slope = (yQ - yT) / (xQ - xT)
+	fp2Sub(&num, &q.y, &t.y)
+	fp2Inv(&den, &den)
+	fp2Mul(&l.slope, &num, &den)
+
+	fp2Mul(&l.c, &l.slope, &t.x)
+	fp2Sub(&l.c, &l.c, &t.y)
+
+	// x3 = slope^2 - xT - xQ, y3 = c - slope x3
+	fp2Sqr(&x3, &l.slope)
+	fp2Sub(&x3, &x3, &t.x)
+	fp2Sub(&x3, &x3, &q.x)
+	fp2Mul(&t.y, &l.slope, &x3)
+	fp2Sub(&t.y, &l.c, &t.y)
+	t.x = x3
+
+	return l
+}
+
+// millerLines computes the lines of the Miller loop for Q in their order of use
+func millerLines(q *G2) []line {
+	lines := make([]line, 0, lineNum)
+
+	if g2IsZero(q) {
+		return append(lines, make([]line, lineNum)...)
+	}
+
+	var (
+		nq     G2
+		base   affineG2
+		t      affineG2
+		q1, q2 G2
+	)
+
+	g2Normalize(&nq, q)
+	base.x, base.y = nq.X, nq.Y
+	t = base
+
+	for i := ateLoopCount.BitLen() - 2; i >= 0; i-- {
+		lines = append(lines, lineDbl(&t))
+
+		if ateLoopCount.Bit(i) == 1 {
+			lines = append(lines, lineAdd(&t, &base))
+		}
+	}
+
+	// Q1 = Frob(Q), Q2 = -Frob^2(Q)
+	g2Frobenius(&q1, &nq)
+	g2Frobenius(&q2, &q1)
+	g2Neg(&q2, &q2)
+
+	lines = append(lines, lineAdd(&t, &affineG2{x: q1.X, y: q1.Y}))
+	lines = append(lines, lineAdd(&t, &affineG2{x: q2.X, y: q2.Y}))
+
+	return lines
+}
+
+// mulByLine multiplies f by the line evaluated at the affine point (xP, yP)
+func mulByLine(f *fp12, l *line, xP, yP *fe) {
+	var e fp12
+
+	e.c[0].b[0].D[0].v = *yP
+	fp2MulFp(&e.c[1].b[0], &l.slope, xP)
+	fp2Neg(&e.c[1].b[0], &e.c[1].b[0])
+	e.c[1].b[1] = l.c
+
+	fp12Mul(f, f, &e)
+}
+
+// millerLoop evaluates the lines at P, the result is one if P is the identity
+func millerLoop(f *fp12, p *G1, lines []line) {
+	fp12SetOne(f)
+
+	if g1IsZero(p) {
+		return
+	}
+
+	var np G1
+
+	g1Normalize(&np, p)
+
+	k := 0
+
+	for i := ateLoopCount.BitLen() - 2; i >= 0; i-- {
+		fp12Sqr(f, f)
+		mulByLine(f, &lines[k], &np.X.v, &np.Y.v)
+		k++
+
+		if ateLoopCount.Bit(i) == 1 {
+			mulByLine(f, &lines[k], &np.X.v, &np.Y.v)
+			k++
+		}
+	}
+
+	mulByLine(f, &lines[k], &np.X.v, &np.Y.v)
+	mulByLine(f, &lines[k+1], &np.X.v, &np.Y.v)
+}
+
+func encodeLines(lines []line) []uint64 {
+	buf := make([]uint64, 0, len(lines)*lineUint64Num)
+
+	for i := range lines {
+		for _, x := range []*Fp2{&lines[i].slope, &lines[i].c} {
+			buf = append(buf, x.D[0].v[:]...)
+			buf = append(buf, x.D[1].v[:]...)
+		}
+	}
+
+	return buf
+}
+
+func decodeLines(buf []uint64) []line {
+	lines := make([]line, lineNum)
+
+	for i := range lines {
+		for j, x := range []*Fp2{&lines[i].slope, &lines[i].c} {
+			offset := i*lineUint64Num + j*2*fpUint64Num
+
+			copy(x.D[0].v[:], buf[offset:offset+fpUint64Num])
+			copy(x.D[1].v[:], buf[offset+fpUint64Num:offset+2*fpUint64Num])
+		}
+	}
+
+	return lines
+}
+
+// finalExp raises f to the power 2u(6u^2 + 3u + 1)(p^12 - 1) / r
+func finalExp(z, f *fp12) {
+	var t0, t1 fp12
+
+	// easy part: f^((p^6 - 1)(p^2 + 1))
+	fp12Inv(&t0, f)
+	fp12Conj(&t1, f)
+	fp12Mul(&t1, &t1, &t0)
+	fp12Frobenius(&t0, &t1)
+	fp12Frobenius(&t0, &t0)
+	fp12Mul(&t1, &t0, &t1)
+
+	// hard part
+	fp12Exp(z, &t1, finalExpHard)
+}
@@ -0,0 +1,442 @@
+//go:build !cgo || purego
+
+package core
+
+import (
+	"math/big"
+)
+
+// The extension tower matches mcl for BN curves:
+// Fp2 = Fp[i] / (i^2 + 1), Fp6 = Fp2[v] / (v^3 - xi) with xi = 9 + i, Fp12 = Fp6[w] / (w^2 - v)
+
+// fp6 is b[0] + b[1] v + b[2] v^2
+type fp6 struct {
+	b [3]Fp2
+}
+
+// fp12 is c[0] + c[1] w
+type fp12 struct {
+	c [2]fp6
+}
+
+// the constants are package level variables rather than set in init, because common.go precomputes
+// the generator lines in its init function, which runs before the init functions of this file
+var (
+	xi = newFp2(9, 1)
+	// frobeniusCoef[k] = xi^(k * (p - 1) / 6) is used to raise w^k to the power p
+	frobeniusCoef = newFrobeniusCoefficients()
+	// twistFrobeniusX = xi^((p - 1) / 3) and twistFrobeniusY = xi^((p - 1) / 2) map the twist point
+	// (x, y) to the twist of the image of its untwisted point under the Frobenius endomorphism
+	twistFrobeniusX, twistFrobeniusY = frobeniusCoef[2], frobeniusCoef[3]
+)
+
+func newFp2(a, b int64) Fp2 {
+	var z Fp2
+
+	fpField.setInt64(&z.D[0].v, a)
+	fpField.setInt64(&z.D[1].v, b)
+
+	return z
+}
+
+func newFrobeniusCoefficients() [6]Fp2 {
+	var (
+		coefficients [6]Fp2
+		gamma        Fp2
+	)
+
+	e := new(big.Int).Sub(fpField.modulusBig, big.NewInt(1))
+	e.Div(e, big.NewInt(6))
+
+	fp2Exp(&gamma, &xi, e)
+	fp2SetOne(&coefficients[0])
+
+	for k := 1; k < 6; k++ {
+		fp2Mul(&coefficients[k], &coefficients[k-1], &gamma)
+	}
+
+	return coefficients
+}
+
+func fp2SetOne(z *Fp2) {
+	z.D[0].v = fpField.one
+	z.D[1].v = fe{}
+}
+
+func fp2IsZero(x *Fp2) bool {
+	return fpField.isZero(&x.D[0].v) && fpField.isZero(&x.D[1].v)
+}
+
+func fp2IsOne(x *Fp2) bool {
+	return fpField.isOne(&x.D[0].v) && fpField.isZero(&x.D[1].v)
+}
+
+func fp2Add(z, x, y *Fp2) {
+	fpField.add(&z.D[0].v, &x.D[0].v, &y.D[0].v)
+	fpField.add(&z.D[1].v, &x.D[1].v, &y.D[1].v)
+}
+
+func fp2Sub(z, x, y *Fp2) {
+	fpField.sub(&z.D[0].v, &x.D[0].v, &y.D[0].v)
+	fpField.sub(&z.D[1].v, &x.D[1].v, &y.D[1].v)
+}
+
+func fp2Neg(z, x *Fp2) {
+	fpField.neg(&z.D[0].v, &x.D[0].v)
+	fpField.neg(&z.D[1].v, &x.D[1].v)
+}
+
+func fp2Conj(z, x *Fp2) {
+	z.D[0] = x.D[0]
+	fpField.neg(&z.D[1].v, &x.D[1].v)
+}
+
+// fp2Mul computes (a + bi)(c + di) = (ac - bd) + ((a + b)(c + d) - ac - bd) i
+func fp2Mul(z, x, y *Fp2) {
+	var ac, bd, s, t fe
+
+	fpField.mul(&ac, &x.D[0].v, &y.D[0].v)
+	fpField.mul(&bd, &x.D[1].v, &y.D[1].v)
+	fpField.add(&s, &x.D[0].v, &x.D[1].v)
+	fpField.add(&t, &y.D[0].v, &y.D[1].v)
+	fpField.mul(&s, &s, &t)
+	fpField.sub(&s, &s, &ac)
+	fpField.sub(&z.D[1].v, &s, &bd)
+	fpField.sub(&z.D[0].v, &ac, &bd)
+}
+
+// fp2MulFp multiplies by the element of the base field
+func fp2MulFp(z, x *Fp2, y *fe) {
+	fpField.mul(&z.D[0].v, &x.D[0].v, y)
+	fpField.mul(&z.D[1].v, &x.D[1].v, y)
+}
+
+// fp2Sqr computes (a + bi)^2 = (a + b)(a - b) + 2ab i
+func fp2Sqr(z, x *Fp2) {
+	var s, d, ab fe
+
+	fpField.add(&s, &x.D[0].v, &x.D[1].v)
+	fpField.sub(&d, &x.D[0].v, &x.D[1].v)
+	fpField.mul(&ab, &x.D[0].v, &x.D[1].v)
+	fpField.mul(&z.D[0].v, &s, &d)
+	fpField.add(&z.D[1].v, &ab, &ab)
+}
+
+// fp2MulByXi computes (a + bi)(9 + i) = (9a - b) + (a + 9b) i
+func fp2MulByXi(z, x *Fp2) {
+	var a9, b9, t fe
+
+	t = x.D[0].v
+	fpField.add(&a9, &t, &t)
+	fpField.add(&a9, &a9, &a9)
+	fpField.add(&a9, &a9, &a9)
+	fpField.add(&a9, &a9, &t)
+
+	fpField.add(&b9, &x.D[1].v, &x.D[1].v)
+	fpField.add(&b9, &b9, &b9)
+	fpField.add(&b9, &b9, &b9)
+	fpField.add(&b9, &b9, &x.D[1].v)
+
+	fpField.sub(&z.D[0].v, &a9, &x.D[1].v)
+	fpField.add(&z.D[1].v, &t, &b9)
+}
+
+// fp2Norm computes a^2 + b^2
+func fp2Norm(z *fe, x *Fp2) {
+	var t fe
+
+	fpField.sqr(z, &x.D[0].v)
+	fpField.sqr(&t, &x.D[1].v)
+	fpField.add(z, z, &t)
+}
+
+// fp2Inv computes (a - bi) / (a^2 + b^2)
+func fp2Inv(z, x *Fp2) {
+	var n fe
+
+	fp2Norm(&n, x)
+	fpField.inverse(&n, &n)
+	fpField.mul(&z.D[0].v, &x.D[0].v, &n)
+	fpField.mul(&z.D[1].v, &x.D[1].v, &n)
+	fpField.neg(&z.D[1].v, &z.D[1].v)
+}
+
+func fp2Exp(z, x *Fp2, e *big.Int) {
+	var r Fp2
+
+	fp2SetOne(&r)
+
+	b := *x
+
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		fp2Sqr(&r, &r)
+
+		if e.Bit(i) == 1 {
+			fp2Mul(&r, &r, &b)
+		}
+	}
+
+	*z = r
+}
+
+// fp2Legendre returns the quadratic character of x, which is the character of its norm
+func fp2Legendre(x *Fp2) int {
+	var n fe
+
+	fp2Norm(&n, x)
+
+	return fpField.legendre(&n)
+}
+
+// fp2Sqrt computes the square root the same way as mcl:
+// for x = c + di, y = a + bi with a^2 = (c +/- sqrt(c^2 + d^2)) / 2 and b = d / 2a
+func fp2Sqrt(z, x *Fp2) bool {
+	var t1, t2, half fe
+
+	if fpField.isZero(&x.D[1].v) {
+		if fpField.sqrt(&t1, &x.D[0].v) {
+			z.D[0].v = t1
+			z.D[1].v = fe{}
+
+			return true
+		}
+
+		fpField.neg(&t2, &x.D[0].v)
+		fpField.sqrt(&t1, &t2)
+		z.D[0].v = fe{}
+		z.D[1].v = t1
+
+		return true
+	}
+
+	fp2Norm(&t1, x)
+
+	if !fpField.sqrt(&t1, &t1) {
+		return false
+	}
+
+	fpField.setInt64(&half, 2)
+	fpField.inverse(&half, &half)
+
+	fpField.add(&t2, &x.D[0].v, &t1)
+	fpField.mul(&t2, &t2, &half)
+
+	if !fpField.sqrt(&t2, &t2) {
+		fpField.sub(&t2, &x.D[0].v, &t1)
+		fpField.mul(&t2, &t2, &half)
+		fpField.sqrt(&t2, &t2)
+	}
+
+	var b fe
+
+	fpField.add(&t1, &t2, &t2)
+	fpField.inverse(&t1, &t1)
+	fpField.mul(&b, &x.D[1].v, &t1)
+
+	z.D[0].v = t2
+	z.D[1].v = b
+
+	return true
+}
+
+func fp6Add(z, x, y *fp6) {
+	for i := range z.b {
+		fp2Add(&z.b[i], &x.b[i], &y.b[i])
+	}
+}
+
+func fp6Sub(z, x, y *fp6) {
+	for i := range z.b {
+		fp2Sub(&z.b[i], &x.b[i], &y.b[i])
+	}
+}
+
+func fp6Neg(z, x *fp6) {
+	for i := range z.b {
+		fp2Neg(&z.b[i], &x.b[i])
+	}
+}
+
+func fp6IsZero(x *fp6) bool {
+	return fp2IsZero(&x.b[0]) && fp2IsZero(&x.b[1]) && fp2IsZero(&x.b[2])
+}
+
+func fp6Mul(z, x, y *fp6) {
+	var t0, t1, t2, s, u, c0, c1, c2 Fp2
+
+	fp2Mul(&t0, &x.b[0], &y.b[0])
+	fp2Mul(&t1, &x.b[1], &y.b[1])
+	fp2Mul(&t2, &x.b[2], &y.b[2])
+
+	// c0 = t0 + xi((x1 + x2)(y1 + y2) - t1 - t2)
+	fp2Add(&s, &x.b[1], &x.b[2])
+	fp2Add(&u, &y.b[1], &y.b[2])
+	fp2Mul(&c0, &s, &u)
+	fp2Sub(&c0, &c0, &t1)
+	fp2Sub(&c0, &c0, &t2)
+	fp2MulByXi(&c0, &c0)
+	fp2Add(&c0, &c0, &t0)
+
+	// c1 = (x0 + x1)(y0 + y1) - t0 - t1 + xi t2
+	fp2Add(&s, &x.b[0], &x.b[1])
+	fp2Add(&u, &y.b[0], &y.b[1])
+	fp2Mul(&c1, &s, &u)
+	fp2Sub(&c1, &c1, &t0)
+	fp2Sub(&c1, &c1, &t1)
+	fp2MulByXi(&s, &t2)
+	fp2Add(&c1, &c1, &s)
+
+	// c2 = (x0 + x2)(y0 + y2) - t0 - t2 + t1
+	fp2Add(&s, &x.b[0], &x.b[2])
+	fp2Add(&u, &y.b[0], &y.b[2])
+	fp2Mul(&c2, &s, &u)
+	fp2Sub(&c2, &c2, &t0)
+	fp2Sub(&c2, &c2, &t2)
+	fp2Add(&c2, &c2, &t1)
+
+	z.b[0], z.b[1], z.b[2] = c0, c1, c2
+}
+
+// fp6MulByV computes (b0 + b1 v + b2 v^2) v = xi b2 + b0 v + b1 v^2
+func fp6MulByV(z, x *fp6) {
+	var t Fp2
+
+	fp2MulByXi(&t, &x.b[2])
+	z.b[2] = x.b[1]
+	z.b[1] = x.b[0]
+	z.b[0] = t
+}
+
+func fp6Inv(z, x *fp6) {
+	var c0, c1, c2, t Fp2
+
+	// c0 = b0^2 - xi b1 b2
+	fp2Sqr(&c0, &x.b[0])
+	fp2Mul(&t, &x.b[1], &x.b[2])
+	fp2MulByXi(&t, &t)
+	fp2Sub(&c0, &c0, &t)
+
+	// c1 = xi b2^2 - b0 b1
+	fp2Sqr(&c1, &x.b[2])
+	fp2MulByXi(&c1, &c1)
+	fp2Mul(&t, &x.b[0], &x.b[1])
+	fp2Sub(&c1, &c1, &t)
+
+	// c2 = b1^2 - b0 b2
+	fp2Sqr(&c2, &x.b[1])
+	fp2Mul(&t, &x.b[0], &x.b[2])
+	fp2Sub(&c2, &c2, &t)
+
+	// n = b0 c0 + xi (b2 c1 + b1 c2)
+	var n, s Fp2
+
+	fp2Mul(&n, &x.b[2], &c1)
+	fp2Mul(&s, &x.b[1], &c2)
+	fp2Add(&n, &n, &s)
+	fp2MulByXi(&n, &n)
+	fp2Mul(&s, &x.b[0], &c0)
+	fp2Add(&n, &n, &s)
+	fp2Inv(&n, &n)
+
+	fp2Mul(&z.b[0], &c0, &n)
+	fp2Mul(&z.b[1], &c1, &n)
+	fp2Mul(&z.b[2], &c2, &n)
+}
+
+func fp12SetOne(z *fp12) {
+	*z = fp12{}
+	fp2SetOne(&z.c[0].b[0])
+}
+
+func fp12IsOne(x *fp12) bool {
+	return fp2IsOne(&x.c[0].b[0]) && fp2IsZero(&x.c[0].b[1]) && fp2IsZero(&x.c[0].b[2]) && fp6IsZero(&x.c[1])
+}
+
+func fp12IsZero(x *fp12) bool {
+	return fp6IsZero(&x.c[0]) && fp6IsZero(&x.c[1])
+}
+
+func fp12Add(z, x, y *fp12) {
+	fp6Add(&z.c[0], &x.c[0], &y.c[0])
+	fp6Add(&z.c[1], &x.c[1], &y.c[1])
+}
+
+func fp12Sub(z, x, y *fp12) {
+	fp6Sub(&z.c[0], &x.c[0], &y.c[0])
+	fp6Sub(&z.c[1], &x.c[1], &y.c[1])
+}
+
+func fp12Neg(z, x *fp12) {
+	fp6Neg(&z.c[0], &x.c[0])
+	fp6Neg(&z.c[1], &x.c[1])
+}
+
+// fp12Conj computes c0 - c1 w, which is x^(p^6)
+func fp12Conj(z, x *fp12) {
+	z.c[0] = x.c[0]
+	fp6Neg(&z.c[1], &x.c[1])
+}
+
+// fp12Mul computes (a0 + a1 w)(b0 + b1 w) = a0 b0 + a1 b1 v + ((a0 + a1)(b0 + b1) - a0 b0 - a1 b1) w
+func fp12Mul(z, x, y *fp12) {
+	var t0, t1, s, u fp6
+
+	fp6Mul(&t0, &x.c[0], &y.c[0])
+	fp6Mul(&t1, &x.c[1], &y.c[1])
+	fp6Add(&s, &x.c[0], &x.c[1])
+	fp6Add(&u, &y.c[0], &y.c[1])
+	fp6Mul(&s, &s, &u)
+	fp6Sub(&s, &s, &t0)
+	fp6Sub(&z.c[1], &s, &t1)
+	fp6MulByV(&t1, &t1)
+	fp6Add(&z.c[0], &t0, &t1)
+}
+
+func fp12Sqr(z, x *fp12) {
+	fp12Mul(z, x, x)
+}
+
+// fp12Inv computes (c0 - c1 w) / (c0^2 - c1^2 v)
+func fp12Inv(z, x *fp12) {
+	var t0, t1 fp6
+
+	fp6Mul(&t0, &x.c[0], &x.c[0])
+	fp6Mul(&t1, &x.c[1], &x.c[1])
+	fp6MulByV(&t1, &t1)
+	fp6Sub(&t0, &t0, &t1)
+	fp6Inv(&t0, &t0)
+
+	fp6Mul(&z.c[0], &x.c[0], &t0)
+	fp6Mul(&z.c[1], &x.c[1], &t0)
+	fp6Neg(&z.c[1], &z.c[1])
+}
+
+func fp12Exp(z, x *fp12, e *big.Int) {
+	var r fp12
+
+	fp12SetOne(&r)
+
+	b := *x
+
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		fp12Sqr(&r, &r)
+
+		if e.Bit(i) == 1 {
+			fp12Mul(&r, &r, &b)
+		}
+	}
+
+	*z = r
+}
+
+// fp12Frobenius computes x^p. For x = sum(a_k w^k), x^p = sum(conj(a_k) xi^(k(p-1)/6) w^k)
+func fp12Frobenius(z, x *fp12) {
+	for k := 0; k < 6; k++ {
+		// w^k = v^(k/2) w^(k%2)
+		a := &x.c[k%2].b[k/2]
+		out := &z.c[k%2].b[k/2]
+
+		fp2Conj(out, a)
+		fp2Mul(out, out, &frobeniusCoef[k])
+	}
+}
@@ -0,0 +1,230 @@
+package core
+
+import (
+	"errors"
+)
+
+// DefaultCiphersuiteID identifies the default scheme: messages are hashed to G1 by HashToG107,
+// i.e. expand_message_xmd with SHA-256 and the Fouque-Tibouchi map of mcl, public keys are in G2
+const DefaultCiphersuiteID = "BLS_SIG_BN254G1_XMD:SHA-256_FT_RO_NUL_"
+
+// Keccak256CiphersuiteID identifies the scheme hashing messages to G1 by HashToG1Keccak256WithDST
+const Keccak256CiphersuiteID = "BLS_SIG_BN254G1_XMD:KECCAK-256_FT_RO_NUL_"
+
+// DefaultPoPDST is the domain separation tag of proofs of possession, which hash the public key
+// with HashToG107WithDST
+const DefaultPoPDST = "BLS_POP_BN254G1_XMD:SHA-256_FT_RO_POP_"
+
+var errInvalidDST = errors.New("domain separation tag must be between 1 and 255 bytes")
+
+// HashToCurve maps the message to G1 under the domain separation tag
+type HashToCurve func(message, dst []byte) (*G1, error)
+
+// Scheme is a BLS signature scheme with its own domain separation tag and hash to curve.
+// It is immutable, so schemes with different tags can be used concurrently in one process
+type Scheme struct {
+	ciphersuiteID string
+	dst           []byte
+	popDST        []byte
+	hashToCurve   HashToCurve
+}
+
+// defaultScheme follows SetDomain and HashToG1 for compatibility with the top-level functions
+var defaultScheme = &Scheme{
+	ciphersuiteID: DefaultCiphersuiteID,
+	popDST:        []byte(DefaultPoPDST),
+	hashToCurve: func(message, _ []byte) (*G1, error) {
+		return HashToG1(message)
+	},
+}
+
+// NewScheme creates the scheme hashing messages with hashToCurve under the given domain separation tag.
+// Its proofs of possession use DefaultPoPDST, see WithPoPDST
+func NewScheme(ciphersuiteID string, dst []byte, hashToCurve HashToCurve) (*Scheme, error) {
+	if len(dst) == 0 || len(dst) > 255 {
+		return nil, errInvalidDST
+	}
+
+	if hashToCurve == nil {
+		return nil, errors.New("hash to curve function must be provided")
+	}
+
+	return &Scheme{
+		ciphersuiteID: ciphersuiteID,
+		dst:           append([]byte{}, dst...),
+		popDST:        []byte(DefaultPoPDST),
+		hashToCurve:   hashToCurve,
+	}, nil
+}
+
+// MustNewScheme is NewScheme panicking on an error, for schemes initialized with package variables
+func MustNewScheme(ciphersuiteID string, dst []byte, hashToCurve HashToCurve) *Scheme {
+	s, err := NewScheme(ciphersuiteID, dst, hashToCurve)
+	if err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// WithPoPDST returns a copy of the scheme generating proofs of possession under the given domain separation tag
+func (s *Scheme) WithPoPDST(dst []byte) (*Scheme, error) {
+	if len(dst) == 0 || len(dst) > 255 {
+		return nil, errInvalidDST
+	}
+
+	res := *s
+	res.popDST = append([]byte{}, dst...)
+
+	return &res, nil
+}
+
+// DefaultScheme returns the scheme used by the top-level functions, which hashes messages with HashToG1
+// under the domain set by SetDomain
+func DefaultScheme() *Scheme {
+	return defaultScheme
+}
+
+// CiphersuiteID returns the identifier of the scheme
+func (s *Scheme) CiphersuiteID() string {
+	return s.ciphersuiteID
+}
+
+// DST returns a copy of the domain separation tag of the scheme
+func (s *Scheme) DST() []byte {
+	if s.dst == nil {
+		return append([]byte{}, GetDomain()...)
+	}
+
+	return append([]byte{}, s.dst...)
+}
+
+// PoPDST returns a copy of the domain separation tag of proofs of possession
+func (s *Scheme) PoPDST() []byte {
+	return append([]byte{}, s.popDST...)
+}
+
+// HashToG1 maps the message to G1 under the domain separation tag of the scheme
+func (s *Scheme) HashToG1(message []byte) (*G1, error) {
+	return s.hashToCurve(message, s.dst)
+}
+
+// Sign generates a signature of the given message
+func (s *Scheme) Sign(key *PrivateKey, message []byte) (*Signature, error) {
+	messagePoint, err := s.HashToG1(message)
+	if err != nil {
+		return nil, err
+	}
+
+	g1 := new(G1)
+
+	G1Mul(g1, messagePoint, key.p)
+
+	return &Signature{p: g1}, nil
+}
+
+// Verify checks the BLS signature of the message against the public key of its signer
+func (s *Scheme) Verify(signature *Signature, publicKey *PublicKey, message []byte) bool {
+	if signature == nil || signature.p == nil || publicKey == nil || publicKey.p == nil {
+		return false
+	}
+
+	messagePoint, err := s.HashToG1(message)
+	if err != nil {
+		return false
+	}
+
+	return signature.verifyPoint(publicKey, messagePoint)
+}
+
+// ProvePossession generates a proof of possession of the private key.
+// The proof is a signature of the serialized public key under the proof of possession domain
+func (s *Scheme) ProvePossession(key *PrivateKey) (*Signature, error) {
+	messagePoint, err := HashToG107WithDST(key.PublicKey().Marshal(), s.popDST)
+	if err != nil {
+		return nil, err
+	}
+
+	g1 := new(G1)
+
+	G1Mul(g1, messagePoint, key.p)
+
+	return &Signature{p: g1}, nil
+}
+
+// VerifyPossession checks the proof of possession generated by ProvePossession
+func (s *Scheme) VerifyPossession(publicKey *PublicKey, proof *Signature) bool {
+	if publicKey == nil || publicKey.p == nil || proof == nil || proof.p == nil {
+		return false
+	}
+
+	messagePoint, err := HashToG107WithDST(publicKey.Marshal(), s.popDST)
+	if err != nil {
+		return false
+	}
+
+	return proof.verifyPoint(publicKey, messagePoint)
+}
+
+// FastAggregateVerify checks the aggregated signature of the same message signed by all of the given keys.
+// It is safe only if every public key has been checked with PublicKey.VerifyPossession,
+// otherwise a rogue key may forge an aggregated signature
+func (s *Scheme) FastAggregateVerify(signature *Signature, publicKeys []*PublicKey, message []byte) bool {
+	if signature == nil || signature.p == nil || len(publicKeys) == 0 {
+		return false
+	}
+
+	for _, pub := range publicKeys {
+		if pub == nil || pub.p == nil {
+			return false
+		}
+	}
+
+	return s.Verify(signature, AggregatePublicKeys(publicKeys), message)
+}
+
+// AggregateVerify checks the aggregated signature of distinct messages, where msgs[i] is signed by pubs[i].
+// Duplicate messages are rejected
+func (s *Scheme) AggregateVerify(sig *Signature, pubs []*PublicKey, msgs [][]byte) bool {
+	if sig == nil || sig.p == nil || len(pubs) == 0 || len(pubs) != len(msgs) {
+		return false
+	}
+
+	seen := make(map[string]struct{}, len(msgs))
+	g1s := make([]G1, len(msgs)+1)
+	g2s := make([]G2, len(pubs)+1)
+
+	g1s[0], g2s[0] = *sig.p, *ellipticCurveG2
+
+	for i, msg := range msgs {
+		if _, exists := seen[string(msg)]; exists {
+			return false
+		}
+
+		seen[string(msg)] = struct{}{}
+
+		if pubs[i] == nil || pubs[i].p == nil {
+			return false
+		}
+
+		messagePoint, err := s.HashToG1(msg)
+		if err != nil {
+			return false
+		}
+
+		G1Neg(&g1s[i+1], messagePoint)
+		g2s[i+1] = *pubs[i].p
+	}
+
+	e := new(GT)
+
+	MillerLoopVec(e, g1s, g2s)
+	FinalExp(e, e)
+
+	return e.IsOne()
+}
+
+// NewBatchVerifier creates an empty BatchVerifier hashing the messages with the scheme
+func (s *Scheme) NewBatchVerifier() *BatchVerifier {
+	return &BatchVerifier{scheme: s}
+}
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheme_DomainSeparation(t *testing.T) {
+	t.Parallel()
+
+	consensus, err := NewScheme("CONSENSUS", []byte("CONSENSUS_BN254G1_XMD:SHA-256_FT_RO_"), HashToG107WithDST)
+	require.NoError(t, err)
+
+	bridge, err := NewScheme("BRIDGE", []byte("BRIDGE_BN254G1_XMD:SHA-256_FT_RO_"), HashToG107WithDST)
+	require.NoError(t, err)
+
+	assert.Equal(t, "CONSENSUS", consensus.CiphersuiteID())
+	assert.Equal(t, []byte("BRIDGE_BN254G1_XMD:SHA-256_FT_RO_"), bridge.DST())
+
+	keys, err := CreateRandomBlsKeys(4)
+	require.NoError(t, err)
+
+	message := []byte("message")
+
+	var wg sync.WaitGroup
+
+	for _, scheme := range []*Scheme{consensus, bridge} {
+		scheme := scheme
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			signatures := make([]*Signature, len(keys))
+
+			for i, key := range keys {
+				signature, err := scheme.Sign(key, message)
+				assert.NoError(t, err)
+				assert.True(t, scheme.Verify(signature, key.PublicKey(), message))
+
+				signatures[i] = signature
+			}
+
+			aggregated := AggregateSignatures(signatures)
+			assert.True(t, scheme.FastAggregateVerify(aggregated, CollectPublicKeys(keys), message))
+		}()
+	}
+
+	wg.Wait()
+
+	signature, err := consensus.Sign(keys[0], message)
+	require.NoError(t, err)
+
+	assert.False(t, bridge.Verify(signature, keys[0].PublicKey(), message))
+	assert.False(t, signature.Verify(keys[0].PublicKey(), message))
+
+	messages := [][]byte{[]byte("a"), []byte("b")}
+	signatures := make([]*Signature, len(messages))
+
+	for i, msg := range messages {
+		signatures[i], err = bridge.Sign(keys[i], msg)
+		require.NoError(t, err)
+	}
+
+	pubs := CollectPublicKeys(keys[:2])
+	aggregated := AggregateSignatures(signatures)
+
+	assert.True(t, bridge.AggregateVerify(aggregated, pubs, messages))
+	assert.False(t, consensus.AggregateVerify(aggregated, pubs, messages))
+
+	batch := bridge.NewBatchVerifier()
+	require.NoError(t, batch.Add(pubs[0], messages[0], signatures[0]))
+	require.NoError(t, batch.Add(pubs[1], messages[1], signatures[1]))
+
+	ok, _ := batch.Verify()
+	assert.True(t, ok)
+
+	batch = NewBatchVerifier()
+	require.NoError(t, batch.Add(pubs[0], messages[0], signatures[0]))
+
+	ok, invalid := batch.Verify()
+	assert.False(t, ok)
+	assert.Equal(t, []int{0}, invalid)
+}
+
+func TestScheme_Default(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	message := []byte("message")
+
+	signature, err := key.Sign(message)
+	require.NoError(t, err)
+
+	schemeSignature, err := DefaultScheme().Sign(key, message)
+	require.NoError(t, err)
+
+	assert.True(t, signature.p.IsEqual(schemeSignature.p))
+	assert.True(t, DefaultScheme().Verify(signature, key.PublicKey(), message))
+	assert.Equal(t, GetDomain(), DefaultScheme().DST())
+	assert.Equal(t, DefaultCiphersuiteID, DefaultScheme().CiphersuiteID())
+
+	// a scheme with the default domain produces the same signatures
+	scheme, err := NewScheme(DefaultCiphersuiteID, GetDomain(), HashToG107WithDST)
+	require.NoError(t, err)
+
+	schemeSignature, err = scheme.Sign(key, message)
+	require.NoError(t, err)
+	assert.True(t, signature.p.IsEqual(schemeSignature.p))
+}
+
+func TestScheme_ProofOfPossession(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	proof, err := key.ProvePossession()
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte(DefaultPoPDST), DefaultScheme().PoPDST())
+	assert.True(t, DefaultScheme().VerifyPossession(key.PublicKey(), proof))
+
+	scheme, err := DefaultScheme().WithPoPDST([]byte("BLS_POP_OTHER_"))
+	require.NoError(t, err)
+
+	// the default scheme is not changed
+	assert.Equal(t, []byte(DefaultPoPDST), DefaultScheme().PoPDST())
+
+	schemeProof, err := scheme.ProvePossession(key)
+	require.NoError(t, err)
+
+	assert.True(t, scheme.VerifyPossession(key.PublicKey(), schemeProof))
+	assert.False(t, scheme.VerifyPossession(key.PublicKey(), proof))
+	assert.False(t, key.PublicKey().VerifyPossession(schemeProof))
+
+	_, err = scheme.WithPoPDST(nil)
+	assert.ErrorIs(t, err, errInvalidDST)
+}
+
+func TestScheme_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewScheme("", nil, HashToG107WithDST)
+	assert.ErrorIs(t, err, errInvalidDST)
+
+	_, err = NewScheme("", make([]byte, 256), HashToG107WithDST)
+	assert.ErrorIs(t, err, errInvalidDST)
+
+	_, err = NewScheme("", []byte("DST"), nil)
+	assert.Error(t, err)
+
+	assert.Panics(t, func() { MustNewScheme("", nil, HashToG107WithDST) })
+	assert.Equal(t, []byte("DST"), MustNewScheme("", []byte("DST"), HashToG107WithDST).DST())
+
+	assert.False(t, DefaultScheme().Verify(nil, nil, nil))
+}
@@ -1,9 +1,22 @@
 package core
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 )
 
+var (
+	// ErrPointNotOnCurve is returned when decoded coordinates do not satisfy the curve equation
+	ErrPointNotOnCurve = errors.New("point is not on the curve")
+	// ErrNotInSubgroup is returned when a decoded point is outside of the prime-order subgroup
+	ErrNotInSubgroup = errors.New("point is not in the prime-order subgroup")
+	// ErrIdentityPoint is returned when a decoded point is the point at infinity
+	ErrIdentityPoint = errors.New("point is the point at infinity")
+
+	errNonCanonicalCoordinate = errors.New("coordinate is not below the field modulus")
+)
+
 func G1ToBytes(p *G1) []byte {
 	G1Normalize(p, p)
 
@@ -40,10 +53,14 @@ func G1FromBytes(raw []byte) (*G1, error) {
 	}
 
 	g1 := new(G1)
+	if isZeroBytes(raw) {
+		return g1, nil
+	}
+
 	offset := 0
 
 	for _, x := range []*Fp{&g1.X, &g1.Y} {
-		if err := x.SetLittleEndian(raw[offset : offset+32]); err != nil {
+		if err := fpFromCanonicalBytes(x, raw[offset:offset+32]); err != nil {
 			return nil, err
 		}
 
@@ -61,10 +78,14 @@ func G2FromBytes(raw []byte) (*G2, error) {
 	}
 
 	g2 := new(G2)
+	if isZeroBytes(raw) {
+		return g2, nil
+	}
+
 	offset := 0
 
 	for _, x := range []*Fp{&g2.X.D[0], &g2.X.D[1], &g2.Y.D[0], &g2.Y.D[1]} {
-		if err := x.SetLittleEndian(raw[offset : offset+32]); err != nil {
+		if err := fpFromCanonicalBytes(x, raw[offset:offset+32]); err != nil {
 			return nil, err
 		}
 
@@ -76,6 +97,231 @@ func G2FromBytes(raw []byte) (*G2, error) {
 	return g2, nil
 }
 
+// The compressed encoding is the little-endian x-coordinate as in G1ToBytes and G2ToBytes with two flags
+// in the most significant bits, which are always zero for a coordinate below the 254-bit modulus:
+// compressedSignFlag is set if y is odd and compressedInfinityFlag is set for the identity.
+// For G2 the sign is the parity of the real part of y, or of its imaginary part if the real part is zero
+const (
+	G1CompressedSize = 32
+	G2CompressedSize = 64
+
+	compressedSignFlag     = 0x80
+	compressedInfinityFlag = 0x40
+)
+
+var errInvalidCompressedFlags = errors.New("invalid flags of the compressed point")
+
+func G1ToCompressedBytes(p *G1) []byte {
+	res := make([]byte, G1CompressedSize)
+
+	if p.IsZero() {
+		res[G1CompressedSize-1] = compressedInfinityFlag
+
+		return res
+	}
+
+	G1Normalize(p, p)
+
+	copy(res, padLeftOrTrim(p.X.Serialize(), 32))
+
+	if p.Y.IsOdd() {
+		res[G1CompressedSize-1] |= compressedSignFlag
+	}
+
+	return res
+}
+
+func G1FromCompressedBytes(raw []byte) (*G1, error) {
+	if len(raw) != G1CompressedSize {
+		return nil, fmt.Errorf("expect length %d but got %d", G1CompressedSize, len(raw))
+	}
+
+	isOdd, isZero, x, err := splitCompressedFlags(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	g1 := new(G1)
+	if isZero {
+		return g1, nil
+	}
+
+	if err := fpFromCanonicalBytes(&g1.X, x); err != nil {
+		return nil, err
+	}
+
+	// y^2 = x^3 + 3
+	b := new(Fp)
+	b.SetInt64(3)
+
+	FpSqr(&g1.Y, &g1.X)
+	FpMul(&g1.Y, &g1.Y, &g1.X)
+	FpAdd(&g1.Y, &g1.Y, b)
+
+	if !FpSquareRoot(&g1.Y, &g1.Y) {
+		return nil, ErrPointNotOnCurve
+	}
+
+	if g1.Y.IsOdd() != isOdd {
+		FpNeg(&g1.Y, &g1.Y)
+	}
+
+	g1.Z.SetInt64(1)
+
+	return g1, nil
+}
+
+func G2ToCompressedBytes(p *G2) []byte {
+	res := make([]byte, G2CompressedSize)
+
+	if p.IsZero() {
+		res[G2CompressedSize-1] = compressedInfinityFlag
+
+		return res
+	}
+
+	G2Normalize(p, p)
+
+	copy(res, padLeftOrTrim(p.X.D[0].Serialize(), 32))
+	copy(res[32:], padLeftOrTrim(p.X.D[1].Serialize(), 32))
+
+	if fp2IsOddForCompression(&p.Y) {
+		res[G2CompressedSize-1] |= compressedSignFlag
+	}
+
+	return res
+}
+
+func G2FromCompressedBytes(raw []byte) (*G2, error) {
+	if len(raw) != G2CompressedSize {
+		return nil, fmt.Errorf("expect length %d but got %d", G2CompressedSize, len(raw))
+	}
+
+	isOdd, isZero, x, err := splitCompressedFlags(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	g2 := new(G2)
+	if isZero {
+		return g2, nil
+	}
+
+	if err := fpFromCanonicalBytes(&g2.X.D[0], x[:32]); err != nil {
+		return nil, err
+	}
+
+	if err := fpFromCanonicalBytes(&g2.X.D[1], x[32:]); err != nil {
+		return nil, err
+	}
+
+	// y^2 = x^3 + 3 / (9 + i)
+	b, xi := new(Fp2), new(Fp2)
+	b.D[0].SetInt64(3)
+	xi.D[0].SetInt64(9)
+	xi.D[1].SetInt64(1)
+	Fp2Div(b, b, xi)
+
+	Fp2Sqr(&g2.Y, &g2.X)
+	Fp2Mul(&g2.Y, &g2.Y, &g2.X)
+	Fp2Add(&g2.Y, &g2.Y, b)
+
+	if !Fp2SquareRoot(&g2.Y, &g2.Y) {
+		return nil, ErrPointNotOnCurve
+	}
+
+	if fp2IsOddForCompression(&g2.Y) != isOdd {
+		Fp2Neg(&g2.Y, &g2.Y)
+	}
+
+	g2.Z.D[0].SetInt64(1)
+
+	return g2, nil
+}
+
+// ValidateG1 checks that the point is on the curve, in the prime-order subgroup and not the identity
+func ValidateG1(p *G1) error {
+	if p.IsZero() {
+		return ErrIdentityPoint
+	}
+
+	if !p.IsValid() {
+		return ErrPointNotOnCurve
+	}
+
+	if !p.IsValidOrder() {
+		return ErrNotInSubgroup
+	}
+
+	return nil
+}
+
+// ValidateG2 checks that the point is on the twist curve, in the prime-order subgroup and not the identity
+func ValidateG2(p *G2) error {
+	if p.IsZero() {
+		return ErrIdentityPoint
+	}
+
+	if !p.IsValid() {
+		return ErrPointNotOnCurve
+	}
+
+	if !p.IsValidOrder() {
+		return ErrNotInSubgroup
+	}
+
+	return nil
+}
+
+// splitCompressedFlags returns the flags and a copy of the coordinate with the flags cleared
+func splitCompressedFlags(raw []byte) (isOdd bool, isZero bool, x []byte, err error) {
+	last := raw[len(raw)-1]
+	isOdd = last&compressedSignFlag != 0
+	isZero = last&compressedInfinityFlag != 0
+
+	x = make([]byte, len(raw))
+	copy(x, raw)
+	x[len(x)-1] &^= compressedSignFlag | compressedInfinityFlag
+
+	if isZero && (isOdd || !isZeroBytes(x)) {
+		return false, false, nil, errInvalidCompressedFlags
+	}
+
+	return isOdd, isZero, x, nil
+}
+
+// fpFromCanonicalBytes reads the 32 little-endian bytes of a coordinate, which must be below the field modulus,
+// so that every point has a single encoding
+func fpFromCanonicalBytes(x *Fp, raw []byte) error {
+	if err := x.Deserialize(raw); err != nil {
+		return fmt.Errorf("%w: %x", errNonCanonicalCoordinate, raw)
+	}
+
+	if !bytes.Equal(padLeftOrTrim(x.Serialize(), 32), raw) {
+		return fmt.Errorf("%w: %x", errNonCanonicalCoordinate, raw)
+	}
+
+	return nil
+}
+
+func fp2IsOddForCompression(y *Fp2) bool {
+	if y.D[0].IsZero() {
+		return y.D[1].IsOdd()
+	}
+
+	return y.D[0].IsOdd()
+}
+
+func isZeroBytes(raw []byte) bool {
+	for _, b := range raw {
+		if b != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
 func padLeftOrTrim(bb []byte, size int) []byte {
 	l := len(bb)
 	if l == size {
@@ -12,11 +12,11 @@ type Signature struct {
 
 // Verify checks the BLS signature of the message against the public key of its signer
 func (s *Signature) Verify(publicKey *PublicKey, message []byte) bool {
-	messagePoint, err := HashToG1(message)
-	if err != nil {
-		return false
-	}
+	return defaultScheme.Verify(s, publicKey, message)
+}
 
+// verifyPoint checks e(s, g2) == e(messagePoint, publicKey). messagePoint is negated in place
+func (s *Signature) verifyPoint(publicKey *PublicKey, messagePoint *G1) bool {
 	e1, e2 := new(GT), new(GT)
 
 	G1Neg(messagePoint, messagePoint)
@@ -28,11 +28,36 @@ func (s *Signature) Verify(publicKey *PublicKey, message []byte) bool {
 	return e1.IsOne()
 }
 
-// VerifyAggregated checks the BLS signature of the message against the aggregated public keys of its signers
+// Clone returns a copy of the signature which does not share its point, e.g. to marshal it
+// without normalizing the point of the original
+func (s *Signature) Clone() *Signature {
+	if s.p == nil {
+		return &Signature{}
+	}
+
+	p := *s.p
+
+	return &Signature{p: &p}
+}
+
+// VerifyAggregated checks the BLS signature of the message against the aggregated public keys of its signers.
+// It is vulnerable to rogue-key attacks, see FastAggregateVerify
 func (s *Signature) VerifyAggregated(publicKeys []*PublicKey, msg []byte) bool {
 	return s.Verify(AggregatePublicKeys(publicKeys), msg)
 }
 
+// FastAggregateVerify checks the aggregated signature of the same message signed by all of the given keys
+// with the default scheme, see Scheme.FastAggregateVerify
+func FastAggregateVerify(signature *Signature, publicKeys []*PublicKey, message []byte) bool {
+	return defaultScheme.FastAggregateVerify(signature, publicKeys, message)
+}
+
+// AggregateVerify checks the aggregated signature of distinct messages with the default scheme,
+// see Scheme.AggregateVerify
+func AggregateVerify(sig *Signature, pubs []*PublicKey, msgs [][]byte) bool {
+	return defaultScheme.AggregateVerify(sig, pubs, msgs)
+}
+
 // Aggregate adds the given signatures
 func (s *Signature) Aggregate(next *Signature) *Signature {
 	newp := new(G1)
@@ -64,9 +89,51 @@ func (s Signature) String() string {
 		s.p.X.GetString(16), s.p.Y.GetString(16), s.p.Z.GetString(16))
 }
 
-// UnmarshalSignature reads the signature from the given byte array
+// MarshalCompressed marshals the signature to 32 bytes
+func (s *Signature) MarshalCompressed() ([]byte, error) {
+	if s.p == nil {
+		return nil, errors.New("cannot marshal empty signature")
+	}
+
+	return G1ToCompressedBytes(s.p), nil
+}
+
+// UnmarshalCompressed reads the compressed signature into s.
+// The point must be in the prime-order subgroup and must not be the identity
+func (s *Signature) UnmarshalCompressed(raw []byte) error {
+	g1, err := G1FromCompressedBytes(raw)
+	if err != nil {
+		return err
+	}
+
+	if err := ValidateG1(g1); err != nil {
+		return err
+	}
+
+	s.p = g1
+
+	return nil
+}
+
+// UnmarshalSignature reads the signature from the given byte array, either compressed or uncompressed
+// depending on its length. The point must be on the curve, in the prime-order subgroup and must not be the identity
 func UnmarshalSignature(raw []byte) (*Signature, error) {
-	g1, err := G1FromBytes(raw)
+	g1, err := g1FromBytesOrCompressed(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateG1(g1); err != nil {
+		return nil, err
+	}
+
+	return &Signature{p: g1}, nil
+}
+
+// UnmarshalSignatureUnchecked reads the signature from the given byte array without validating the point.
+// It should only be used for trusted data, e.g. signatures previously validated and stored locally
+func UnmarshalSignatureUnchecked(raw []byte) (*Signature, error) {
+	g1, err := g1FromBytesOrCompressed(raw)
 	if err != nil {
 		return nil, err
 	}
@@ -74,6 +141,14 @@ func UnmarshalSignature(raw []byte) (*Signature, error) {
 	return &Signature{p: g1}, nil
 }
 
+func g1FromBytesOrCompressed(raw []byte) (*G1, error) {
+	if len(raw) == G1CompressedSize {
+		return G1FromCompressedBytes(raw)
+	}
+
+	return G1FromBytes(raw)
+}
+
 // Aggregate sums the given array of signatures
 func AggregateSignatures(signatures []*Signature) *Signature {
 	newp := new(G1)
@@ -2,6 +2,7 @@ package core
 
 import (
 	"crypto/rand"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -174,3 +175,242 @@ func testGenRandomBytes(t *testing.T, size int) (blk []byte) {
 
 	return
 }
+
+func TestSignature_UnmarshalInvalidPoint(t *testing.T) {
+	t.Parallel()
+
+	_, err := UnmarshalSignature(make([]byte, 64))
+	assert.ErrorIs(t, err, ErrIdentityPoint)
+
+	g1 := new(G1)
+	g1.X.SetInt64(1)
+	g1.Y.SetInt64(5)
+	g1.Z.SetInt64(1)
+
+	raw := G1ToBytes(g1)
+
+	_, err = UnmarshalSignature(raw)
+	assert.ErrorIs(t, err, ErrPointNotOnCurve)
+
+	// trusted data is not validated
+	sig, err := UnmarshalSignatureUnchecked(raw)
+	require.NoError(t, err)
+	assert.True(t, sig.p.IsEqual(g1))
+}
+
+func TestSignature_UnmarshalNonCanonical(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	sig, err := key.Sign([]byte("message"))
+	require.NoError(t, err)
+
+	raw, err := sig.Marshal()
+	require.NoError(t, err)
+
+	pub := key.PublicKey().Marshal()
+
+	// the high bits of a coordinate, masked by SetLittleEndian
+	for _, i := range []int{31, 63} {
+		invalid := append([]byte{}, raw...)
+		invalid[i] |= 0xc0
+
+		_, err = UnmarshalSignature(invalid)
+		assert.ErrorIs(t, err, errNonCanonicalCoordinate)
+	}
+
+	for _, i := range []int{31, 63, 95, 127} {
+		invalid := append([]byte{}, pub...)
+		invalid[i] |= 0xc0
+
+		_, err = UnmarshalPublicKey(invalid)
+		assert.ErrorIs(t, err, errNonCanonicalCoordinate)
+	}
+
+	// x + p, which is reduced to x
+	_, err = UnmarshalSignature(append(testAddFieldOrder(raw[:32]), raw[32:]...))
+	assert.ErrorIs(t, err, errNonCanonicalCoordinate)
+
+	_, err = UnmarshalPublicKey(append(testAddFieldOrder(pub[:32]), pub[32:]...))
+	assert.ErrorIs(t, err, errNonCanonicalCoordinate)
+
+	// the compressed zero written as p
+	_, err = UnmarshalSignature(testAddFieldOrder(make([]byte, G1CompressedSize)))
+	assert.ErrorIs(t, err, errNonCanonicalCoordinate)
+
+	_, err = UnmarshalPublicKey(append(testAddFieldOrder(make([]byte, 32)), make([]byte, 32)...))
+	assert.ErrorIs(t, err, errNonCanonicalCoordinate)
+}
+
+// testAddFieldOrder returns the 32 little-endian bytes of the coordinate plus the field order
+func testAddFieldOrder(coordinate []byte) []byte {
+	x := new(big.Int).SetBytes(reverseTestBytes(coordinate))
+	x.Add(x, fieldOrderTest())
+
+	return reverseTestBytes(x.FillBytes(make([]byte, 32)))
+}
+
+func reverseTestBytes(b []byte) []byte {
+	res := make([]byte, len(b))
+	for i := range b {
+		res[len(b)-1-i] = b[i]
+	}
+
+	return res
+}
+
+func TestSignature_ProofOfPossession(t *testing.T) {
+	t.Parallel()
+
+	validTestMsg := testGenRandomBytes(t, messageSize)
+
+	blsKeys, err := CreateRandomBlsKeys(3)
+	require.NoError(t, err)
+
+	pubKeys := CollectPublicKeys(blsKeys)
+	signatures := make([]*Signature, len(blsKeys))
+
+	for i, key := range blsKeys {
+		proof, err := key.ProvePossession()
+		require.NoError(t, err)
+
+		assert.True(t, pubKeys[i].VerifyPossession(proof))
+		assert.False(t, pubKeys[(i+1)%len(pubKeys)].VerifyPossession(proof))
+
+		// a proof of possession is not a signature of the public key under the message domain
+		sig, err := key.Sign(pubKeys[i].Marshal())
+		require.NoError(t, err)
+		assert.False(t, pubKeys[i].VerifyPossession(sig))
+
+		signatures[i], err = key.Sign(validTestMsg)
+		require.NoError(t, err)
+	}
+
+	aggSignature := AggregateSignatures(signatures)
+
+	assert.True(t, FastAggregateVerify(aggSignature, pubKeys, validTestMsg))
+	assert.False(t, FastAggregateVerify(aggSignature, pubKeys[:2], validTestMsg))
+	assert.False(t, FastAggregateVerify(aggSignature, nil, validTestMsg))
+	assert.False(t, FastAggregateVerify(&Signature{}, pubKeys, validTestMsg))
+}
+
+func TestSignature_AggregateVerify(t *testing.T) {
+	t.Parallel()
+
+	blsKeys, err := CreateRandomBlsKeys(participantsNumber)
+	require.NoError(t, err)
+
+	pubKeys := CollectPublicKeys(blsKeys)
+	messages := make([][]byte, len(blsKeys))
+	signatures := make([]*Signature, len(blsKeys))
+
+	for i, key := range blsKeys {
+		messages[i] = testGenRandomBytes(t, 32)
+
+		signatures[i], err = key.Sign(messages[i])
+		require.NoError(t, err)
+	}
+
+	aggSignature := AggregateSignatures(signatures)
+
+	assert.True(t, AggregateVerify(aggSignature, pubKeys, messages))
+	assert.False(t, AggregateVerify(aggSignature, pubKeys[1:], messages[1:]))
+	assert.False(t, AggregateVerify(aggSignature, pubKeys, messages[1:]))
+	assert.False(t, AggregateVerify(aggSignature, nil, nil))
+
+	swapped := append([][]byte{messages[1], messages[0]}, messages[2:]...)
+	assert.False(t, AggregateVerify(aggSignature, pubKeys, swapped))
+
+	// the same message signed twice is rejected even if the signature is valid
+	sig1, err := blsKeys[0].Sign(messages[0])
+	require.NoError(t, err)
+
+	sig2, err := blsKeys[1].Sign(messages[0])
+	require.NoError(t, err)
+
+	assert.False(t, AggregateVerify(sig1.Aggregate(sig2), pubKeys[:2], [][]byte{messages[0], messages[0]}))
+}
+
+func TestSignature_Clone(t *testing.T) {
+	t.Parallel()
+
+	keys, err := CreateRandomBlsKeys(2)
+	require.NoError(t, err)
+
+	signatures := make([]*Signature, len(keys))
+
+	for i, key := range keys {
+		signatures[i], err = key.Sign([]byte("abc"))
+		require.NoError(t, err)
+	}
+
+	// the points of the sums are not normalized, marshaling the clones leaves them as they are
+	signature := AggregateSignatures(signatures)
+	publicKey := keys[0].PublicKey().Aggregate(keys[1].PublicKey())
+	before, beforeKey := *signature.p, *publicKey.p
+
+	raw, err := signature.Clone().Marshal()
+	require.NoError(t, err)
+
+	expected, err := AggregateSignatures(signatures).Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, expected, raw)
+	assert.Equal(t, keys[0].PublicKey().Aggregate(keys[1].PublicKey()).Marshal(), publicKey.Clone().Marshal())
+
+	assert.Equal(t, before, *signature.p)
+	assert.Equal(t, beforeKey, *publicKey.p)
+
+	assert.Nil(t, new(Signature).Clone().p)
+	assert.Nil(t, new(PublicKey).Clone().p)
+}
+
+func TestSignature_MarshalCompressed(t *testing.T) {
+	t.Parallel()
+
+	key, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	for i := 0; i < 8; i++ {
+		sig, err := key.Sign(testGenRandomBytes(t, messageSize))
+		require.NoError(t, err)
+
+		compressed, err := sig.MarshalCompressed()
+		require.NoError(t, err)
+		require.Len(t, compressed, G1CompressedSize)
+
+		// same layout as the serialization of mcl
+		assert.Equal(t, sig.p.Serialize(), compressed)
+
+		decoded := new(Signature)
+		require.NoError(t, decoded.UnmarshalCompressed(compressed))
+		assert.True(t, decoded.p.IsEqual(sig.p))
+
+		// the length selects the encoding
+		decoded, err = UnmarshalSignature(compressed)
+		require.NoError(t, err)
+		assert.True(t, decoded.p.IsEqual(sig.p))
+	}
+
+	identity := G1ToCompressedBytes(new(G1))
+	assert.Equal(t, byte(compressedInfinityFlag), identity[G1CompressedSize-1])
+
+	g1, err := G1FromCompressedBytes(identity)
+	require.NoError(t, err)
+	assert.True(t, g1.IsZero())
+
+	_, err = UnmarshalSignature(identity)
+	assert.ErrorIs(t, err, ErrIdentityPoint)
+
+	identity[0] = 1
+	_, err = G1FromCompressedBytes(identity)
+	assert.ErrorIs(t, err, errInvalidCompressedFlags)
+
+	// x = 0 is not on the curve since 3 is not a square
+	_, err = UnmarshalSignature(make([]byte, G1CompressedSize))
+	assert.ErrorIs(t, err, ErrPointNotOnCurve)
+
+	_, err = new(Signature).MarshalCompressed()
+	assert.Error(t, err)
+}
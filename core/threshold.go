@@ -0,0 +1,242 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var (
+	errInvalidThreshold    = errors.New("threshold must be between 1 and the total number of shares")
+	errNotEnoughShares     = errors.New("not enough valid shares")
+	errInvalidShareIndex   = errors.New("share index must be greater than zero")
+	errDuplicateShareIndex = errors.New("duplicate share index")
+	errNilShare            = errors.New("share must not be nil")
+)
+
+// KeyShare is a share of the group private key held by the participant with the given index.
+// Indices start from 1, because the group private key is the evaluation of the polynomial at 0
+type KeyShare struct {
+	Index uint64
+	Key   *PrivateKey
+}
+
+// PublicKeyShare is the public key of the KeyShare with the same index
+type PublicKeyShare struct {
+	Index uint64
+	Key   *PublicKey
+}
+
+// SignatureShare is a partial signature created by the KeyShare with the same index
+type SignatureShare struct {
+	Index     uint64
+	Signature *Signature
+}
+
+// SplitPrivateKey splits the private key into total shares, any threshold of which
+// can produce a signature verifiable by the public key of the original private key
+func SplitPrivateKey(key *PrivateKey, threshold, total int) ([]*KeyShare, error) {
+	if threshold < 1 || threshold > total {
+		return nil, errInvalidThreshold
+	}
+
+	coefficients := make([]Fr, threshold)
+	coefficients[0] = *key.p
+
+	for i := 1; i < threshold; i++ {
+		if !coefficients[i].SetByCSPRNG() {
+			return nil, errPrivateKeyGenerator
+		}
+	}
+
+	shares := make([]*KeyShare, total)
+
+	for i := 0; i < total; i++ {
+		x, y := new(Fr), new(Fr)
+		x.SetInt64(int64(i + 1))
+
+		if err := FrEvaluatePolynomial(y, coefficients, x); err != nil {
+			return nil, err
+		}
+
+		shares[i] = &KeyShare{Index: uint64(i + 1), Key: &PrivateKey{p: y}}
+	}
+
+	return shares, nil
+}
+
+// PublicKey returns the public key share of the key share
+func (s *KeyShare) PublicKey() *PublicKeyShare {
+	return &PublicKeyShare{Index: s.Index, Key: s.Key.PublicKey()}
+}
+
+// Sign generates a partial signature of the given message
+func (s *KeyShare) Sign(message []byte) (*SignatureShare, error) {
+	signature, err := s.Key.Sign(message)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignatureShare{Index: s.Index, Signature: signature}, nil
+}
+
+// VerifyShare checks the partial signature of the message against the public key share
+func (p *PublicKeyShare) VerifyShare(share *SignatureShare, message []byte) bool {
+	if share == nil || share.Signature == nil || share.Signature.p == nil || p.Key == nil || p.Key.p == nil {
+		return false
+	}
+
+	return share.Index == p.Index && share.Signature.Verify(p.Key, message)
+}
+
+// CollectPublicKeyShares collects public key shares from slice of key shares
+func CollectPublicKeyShares(shares []*KeyShare) []*PublicKeyShare {
+	pubShares := make([]*PublicKeyShare, len(shares))
+
+	for i, share := range shares {
+		pubShares[i] = share.PublicKey()
+	}
+
+	return pubShares
+}
+
+// CombineSignatures verifies the partial signatures of the message against the public key shares
+// and recombines threshold of the valid ones into the signature of the group public key.
+// Invalid partial signatures and partial signatures without a public key share are skipped,
+// nil entries are rejected
+func CombineSignatures(
+	pubShares []*PublicKeyShare, shares []*SignatureShare, message []byte, threshold int,
+) (*Signature, error) {
+	if threshold < 1 {
+		return nil, errInvalidThreshold
+	}
+
+	pubByIndex := make(map[uint64]*PublicKeyShare, len(pubShares))
+	for i, pub := range pubShares {
+		if pub == nil {
+			return nil, fmt.Errorf("%w: public key share %d", errNilShare, i)
+		}
+
+		pubByIndex[pub.Index] = pub
+	}
+
+	for i, share := range shares {
+		if share == nil {
+			return nil, fmt.Errorf("%w: partial signature %d", errNilShare, i)
+		}
+	}
+
+	valid := make([]*SignatureShare, 0, threshold)
+	used := make(map[uint64]struct{}, threshold)
+
+	for _, share := range shares {
+		if len(valid) == threshold {
+			break
+		}
+
+		if _, exists := used[share.Index]; exists {
+			continue
+		}
+
+		pub, exists := pubByIndex[share.Index]
+		if !exists || !pub.VerifyShare(share, message) {
+			continue
+		}
+
+		valid = append(valid, share)
+		used[share.Index] = struct{}{}
+	}
+
+	if len(valid) < threshold {
+		return nil, fmt.Errorf("%w: %d of %d", errNotEnoughShares, len(valid), threshold)
+	}
+
+	return RecoverSignature(valid)
+}
+
+// RecoverSignature recombines the partial signatures into the signature of the group public key
+// using Lagrange interpolation. Partial signatures are not verified, see CombineSignatures
+func RecoverSignature(shares []*SignatureShare) (*Signature, error) {
+	xVec, err := shareIndicesToFr(len(shares), func(i int) uint64 { return shares[i].Index })
+	if err != nil {
+		return nil, err
+	}
+
+	yVec := make([]G1, len(shares))
+	for i, share := range shares {
+		yVec[i] = *share.Signature.p
+	}
+
+	g1 := new(G1)
+	if err := G1LagrangeInterpolation(g1, xVec, yVec); err != nil {
+		return nil, err
+	}
+
+	return &Signature{p: g1}, nil
+}
+
+// RecoverPublicKey recombines threshold public key shares into the group public key
+// using Lagrange interpolation
+func RecoverPublicKey(shares []*PublicKeyShare) (*PublicKey, error) {
+	xVec, err := shareIndicesToFr(len(shares), func(i int) uint64 { return shares[i].Index })
+	if err != nil {
+		return nil, err
+	}
+
+	yVec := make([]G2, len(shares))
+	for i, share := range shares {
+		yVec[i] = *share.Key.p
+	}
+
+	g2 := new(G2)
+	if err := G2LagrangeInterpolation(g2, xVec, yVec); err != nil {
+		return nil, err
+	}
+
+	return &PublicKey{p: g2}, nil
+}
+
+// RecoverPrivateKey recombines threshold key shares into the group private key
+// using Lagrange interpolation
+func RecoverPrivateKey(shares []*KeyShare) (*PrivateKey, error) {
+	xVec, err := shareIndicesToFr(len(shares), func(i int) uint64 { return shares[i].Index })
+	if err != nil {
+		return nil, err
+	}
+
+	yVec := make([]Fr, len(shares))
+	for i, share := range shares {
+		yVec[i] = *share.Key.p
+	}
+
+	fr := new(Fr)
+	if err := FrLagrangeInterpolation(fr, xVec, yVec); err != nil {
+		return nil, err
+	}
+
+	return &PrivateKey{p: fr}, nil
+}
+
+func shareIndicesToFr(n int, index func(int) uint64) ([]Fr, error) {
+	xVec := make([]Fr, n)
+	seen := make(map[uint64]struct{}, n)
+
+	for i := 0; i < n; i++ {
+		idx := index(i)
+		if idx == 0 {
+			return nil, errInvalidShareIndex
+		}
+
+		if _, exists := seen[idx]; exists {
+			return nil, fmt.Errorf("%w: %d", errDuplicateShareIndex, idx)
+		}
+
+		seen[idx] = struct{}{}
+
+		if err := xVec[i].SetString(strconv.FormatUint(idx, 10), 10); err != nil {
+			return nil, err
+		}
+	}
+
+	return xVec, nil
+}
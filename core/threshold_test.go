@@ -0,0 +1,88 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThreshold_SignAndCombine(t *testing.T) {
+	t.Parallel()
+
+	const (
+		threshold = 3
+		total     = 5
+	)
+
+	validTestMsg, invalidTestMsg := testGenRandomBytes(t, messageSize), testGenRandomBytes(t, messageSize)
+
+	groupKey, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	keyShares, err := SplitPrivateKey(groupKey, threshold, total)
+	require.NoError(t, err)
+	require.Len(t, keyShares, total)
+
+	pubShares := CollectPublicKeyShares(keyShares)
+
+	sigShares := make([]*SignatureShare, total)
+
+	for i, keyShare := range keyShares {
+		sigShares[i], err = keyShare.Sign(validTestMsg)
+		require.NoError(t, err)
+
+		assert.True(t, pubShares[i].VerifyShare(sigShares[i], validTestMsg))
+		assert.False(t, pubShares[i].VerifyShare(sigShares[i], invalidTestMsg))
+	}
+
+	// any threshold of shares recombines into the same signature
+	for _, subset := range [][]int{{0, 1, 2}, {2, 3, 4}, {4, 0, 2}} {
+		shares := make([]*SignatureShare, 0, threshold)
+		for _, i := range subset {
+			shares = append(shares, sigShares[i])
+		}
+
+		signature, err := CombineSignatures(pubShares, shares, validTestMsg, threshold)
+		require.NoError(t, err)
+
+		assert.True(t, signature.Verify(groupKey.PublicKey(), validTestMsg))
+		assert.False(t, signature.Verify(groupKey.PublicKey(), invalidTestMsg))
+	}
+
+	// invalid partial signatures are skipped
+	invalidShare, err := keyShares[0].Sign(invalidTestMsg)
+	require.NoError(t, err)
+
+	signature, err := CombineSignatures(
+		pubShares, []*SignatureShare{invalidShare, sigShares[1], sigShares[1], sigShares[2], sigShares[3]},
+		validTestMsg, threshold)
+	require.NoError(t, err)
+	assert.True(t, signature.Verify(groupKey.PublicKey(), validTestMsg))
+
+	_, err = CombineSignatures(pubShares, []*SignatureShare{invalidShare, sigShares[1], sigShares[2]},
+		validTestMsg, threshold)
+	assert.ErrorIs(t, err, errNotEnoughShares)
+
+	// nil entries are rejected instead of dereferenced
+	_, err = CombineSignatures(pubShares, []*SignatureShare{sigShares[0], nil, sigShares[1], sigShares[2]},
+		validTestMsg, threshold)
+	assert.ErrorIs(t, err, errNilShare)
+
+	_, err = CombineSignatures(append([]*PublicKeyShare{nil}, pubShares...), sigShares, validTestMsg, threshold)
+	assert.ErrorIs(t, err, errNilShare)
+
+	groupPub, err := RecoverPublicKey(pubShares[1:4])
+	require.NoError(t, err)
+	assert.True(t, groupPub.p.IsEqual(groupKey.PublicKey().p))
+
+	recoveredKey, err := RecoverPrivateKey(keyShares[2:])
+	require.NoError(t, err)
+	assert.True(t, recoveredKey.p.IsEqual(groupKey.p))
+
+	_, err = RecoverSignature([]*SignatureShare{sigShares[0], sigShares[0], sigShares[1]})
+	assert.ErrorIs(t, err, errDuplicateShareIndex)
+
+	_, err = SplitPrivateKey(groupKey, total+1, total)
+	assert.ErrorIs(t, err, errInvalidThreshold)
+}
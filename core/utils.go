@@ -3,6 +3,9 @@ package core
 import (
 	"crypto/sha256"
 	"errors"
+	"hash"
+
+	"golang.org/x/crypto/sha3"
 )
 
 // CreateRandomBlsKeys creates an slice of random private keys
@@ -43,13 +46,38 @@ func HashToG103(message []byte) (*G1, error) {
 
 // HashToG107 converts message to G1 point https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-hash-to-curve-07
 func HashToG107(message []byte) (*G1, error) {
-	hashRes, err := hashToFpXMDSHA256(message, GetDomain(), 2)
+	return HashToG107WithDST(message, GetDomain())
+}
+
+// HashToG107WithDST is HashToG107 under the given domain separation tag
+func HashToG107WithDST(message []byte, domain []byte) (*G1, error) {
+	hashRes, err := hashToFpXMDSHA256(message, domain, 2)
 	if err != nil {
 		return nil, err
 	}
 
+	return mapToG1Sum(hashRes[0], hashRes[1])
+}
+
+// HashToG1Keccak256 converts message to G1 point as HashToG107 but expands the message with Keccak-256
+// instead of SHA-256, which is cheaper to reproduce on chain. It can be set as HashToG1
+func HashToG1Keccak256(message []byte) (*G1, error) {
+	return HashToG1Keccak256WithDST(message, GetDomain())
+}
+
+// HashToG1Keccak256WithDST is HashToG1Keccak256 under the given domain separation tag.
+// expand_message_xmd uses the 136 bytes rate of Keccak-256 as the size of Z_pad
+func HashToG1Keccak256WithDST(message []byte, domain []byte) (*G1, error) {
+	hashRes, err := hashToFpXMD(sha3.NewLegacyKeccak256, message, domain, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapToG1Sum(hashRes[0], hashRes[1])
+}
+
+func mapToG1Sum(u0, u1 *Fp) (*G1, error) {
 	p0, p1 := new(G1), new(G1)
-	u0, u1 := hashRes[0], hashRes[1]
 
 	if err := MapToG1(p0, u0); err != nil {
 		return nil, err
@@ -65,8 +93,38 @@ func HashToG107(message []byte) (*G1, error) {
 	return p0, nil
 }
 
+// HashToG2 converts message to G2 point under the given domain separation tag. The field elements are derived
+// with expand_message_xmd as for HashToG107 and mapped by MapToG2, which clears the cofactor of the twist
+func HashToG2(message []byte, domain []byte) (*G2, error) {
+	hashRes, err := hashToFpXMDSHA256(message, domain, 4)
+	if err != nil {
+		return nil, err
+	}
+
+	p0, p1 := new(G2), new(G2)
+	u0 := Fp2{D: [2]Fp{*hashRes[0], *hashRes[1]}}
+	u1 := Fp2{D: [2]Fp{*hashRes[2], *hashRes[3]}}
+
+	if err := MapToG2(p0, &u0); err != nil {
+		return nil, err
+	}
+
+	if err := MapToG2(p1, &u1); err != nil {
+		return nil, err
+	}
+
+	G2Add(p0, p0, p1)
+	G2Normalize(p0, p0)
+
+	return p0, nil
+}
+
 func hashToFpXMDSHA256(msg []byte, domain []byte, count int) ([]*Fp, error) {
-	randBytes, err := expandMsgSHA256XMD(msg, domain, count*48)
+	return hashToFpXMD(sha256.New, msg, domain, count)
+}
+
+func hashToFpXMD(newHash func() hash.Hash, msg []byte, domain []byte, count int) ([]*Fp, error) {
+	randBytes, err := expandMsgXMD(newHash, msg, domain, count*48)
 	if err != nil {
 		return nil, err
 	}
@@ -84,12 +142,21 @@ func hashToFpXMDSHA256(msg []byte, domain []byte, count int) ([]*Fp, error) {
 }
 
 func expandMsgSHA256XMD(msg []byte, domain []byte, outLen int) ([]byte, error) {
-	h := sha256.New()
+	return expandMsgXMD(sha256.New, msg, domain, outLen)
+}
+
+// expandMsgXMD is expand_message_xmd of RFC 9380, Z_pad is a zero block of the hash function
+func expandMsgXMD(newHash func() hash.Hash, msg []byte, domain []byte, outLen int) ([]byte, error) {
+	h := newHash()
 
 	if len(domain) > 255 {
 		return nil, errors.New("invalid domain length")
 	}
 
+	if outLen > 255*h.Size() || outLen > 0xffff {
+		return nil, errors.New("invalid output length")
+	}
+
 	domainLen := uint8(len(domain))
 	// DST_prime = DST || I2OSP(len(DST), 1)
 	// b_0 = H(Z_pad || msg || l_i_b_str || I2OSP(0, 1) || DST_prime)
@@ -1,10 +1,13 @@
 package core
 
 import (
+	"encoding/hex"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
 )
 
 func Test_SingleSign(t *testing.T) {
@@ -77,3 +80,105 @@ func Test_MarshalMessageToBigInt(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, bytes, 64)
 }
+
+// uniform is the output of expand_message_xmd, u0 and u1 are the field elements hashed from it. They were
+// cross-checked against ExpandMsgXmd of gnark-crypto (field/hash) with its SHA-256 replaced by the Keccak-256
+// of golang.org/x/crypto/sha3 and its reduction of 48 bytes big endian chunks modulo p.
+// point is the sum of the MapToG1 of u0 and u1, encoded as for the EVM precompiles. MapToG1 is the map of mcl,
+// not the SVDW map of gnark-crypto, so point was generated by the mcl backend and the pure Go backend must match it
+func Test_HashToG1Keccak256Vectors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		msg     string
+		uniform string
+		u0, u1  string
+		point   string
+	}{
+		{
+			msg: "",
+			uniform: "3589f1e150ccf7658a5444917c7eb5fec84156784720cad507510dac7c64c7cb2d22766c8ef7b3ee1b08e03a4c7cc56d" +
+				"db2cf54d3533e76366e2796daa4fb9fefb322ecd224016653c7d4dd4640e8ba6ba8bc6c0f5bc8153edd8ecaebc48da3b",
+			u0: "20aa6f7f18b38ba817986e218fe230892c87b0abaea76a5f81fbbe010f4496f9",
+			u1: "1b113d04ae1c0c6c6d1f9d7ce2a039f1966765bcb4ce8023a60bc898ef77eb95",
+			point: "2fa617330a8f2183293b98ed2af78b2acc4bb03935a385001d52386c25f80aa8" +
+				"1d2db7d6c58f31ab01dd3ae1a7c0ddccfbdbfa2536dd3d10d43daedeaebd7f86",
+		},
+		{
+			msg: "abc",
+			uniform: "a7829ba1e264349c7e17ebc455bb6f8eb5eda68d6d3a09d36f0844dc31c929f57ef958b64cb17ee0c449fb5b668a6783" +
+				"5232aff94987c5861ffc57ada7ffc7cde24b6e810a8be8507d14113a2d208bd5af670455dd05f913f5fba7ad7b3ae02c",
+			u0: "112f98a21ca325374a9e65339adde3b94c14b05e86c4626c76e2ad2791e58ba9",
+			u1: "16d0dd86662130909c1b385292902f6fc038e97c025d9a9b852234f9f8c2db69",
+			point: "1986e74fda03081412991d1fcb5d5ec6f69ad03ac819685df9095ad9a21ad957" +
+				"1d22bc17108b0572accda15bdc879210060ca6fffbfb48f171eeb608a5af1b3d",
+		},
+		{
+			msg: "abcdef0123456789",
+			uniform: "5249ca5eb6a77db537ae129efecdea2a04fa20cd93b8215282530d50c2e71f1040096fa8846905e032822f4977ab76fc" +
+				"33e4bc143e80d8492b3744161e50ef0e61b77f490c162375ade050e3f32a036519b935c1c6fed53009fb671abb9b4ccb",
+			u0: "6ebd447bb2907c517aad02fa0c6ffbaa1949a51885e35d7388cda98610ccf36",
+			u1: "1ff7ad33819b3410affc072d35198695e109bc0544505999297af0ef15d90640",
+			point: "13b0785667ce33abd3989624502d7417be2130039c443cea747a674d04129e30" +
+				"025df4fa14b3386ef62cb3a803e00814c8b001e78b843e632d99e0f539e40797",
+		},
+		{
+			msg: "q128_" + strings.Repeat("q", 128),
+			uniform: "993d4a915a99b387ae4d400cb15bfc54f737929f96f119457ead394d6f6fac6615c0a47e55c0ed2a0b42aff0b4f39569" +
+				"150cb70c708f4cca465ab4c27a7c36024e1eef42f6fdca887add308dfac5bededa85d1aa489ee510965516ed142b7bd3",
+			u0: "272c7777a6c5a28536ed1f1ad4c9e33759cea9b3c791aa46a6a8b616d303776e",
+			u1: "54a46417ee828a2c63f03e7258bcace491c0c733f6ec1d160541c4021fde93d",
+			point: "19e7fd60c131ed539196675741d616ac20f3ee1ae20c1023a0cc0c769de1a20d" +
+				"017e67f680784f4068d6c5d665a82bfbd0b0bfcb46280ce6df1274294dc26b36",
+		},
+		{
+			msg: "a512_" + strings.Repeat("a", 512),
+			uniform: "5ad6f580bfeabef855b675b5e09122f98c134c93b4eba8caf3129fd873447f207b5eebf0a715d6465d8f4f3f9eea3286" +
+				"3aaa30fa0a4daec0868720997c7e4870453b63acea0963213fbcb57f8866b1b878245373d0e0d84caa9ef7dd2e1b9211",
+			u0: "1ef137527d336fc0f1f6c6867cf9d952fecfc9cf0f2ab4f9e3f4b4330da9c83",
+			u1: "1e80a7734d9f3eb659fc99eee22bc3c6c186d0342a8add2547713123a1951486",
+			point: "041534779526ce086c4dadcd3c4df19c33de9feadf68c5cfbbc3f20545dc2f5b" +
+				"2ccbb880de61c37eb07cd5c03f6f0576808241320ab0a3ff4fd64a3f22079f65",
+		},
+	}
+
+	for _, c := range cases {
+		uniform, err := expandMsgXMD(sha3.NewLegacyKeccak256, []byte(c.msg), GetDomain(), 96)
+		require.NoError(t, err)
+		assert.Equal(t, c.uniform, hex.EncodeToString(uniform))
+
+		u, err := hashToFpXMD(sha3.NewLegacyKeccak256, []byte(c.msg), GetDomain(), 2)
+		require.NoError(t, err)
+		assert.Equal(t, c.u0, u[0].GetString(16))
+		assert.Equal(t, c.u1, u[1].GetString(16))
+
+		p, err := HashToG1Keccak256([]byte(c.msg))
+		require.NoError(t, err)
+		assert.Equal(t, c.point, hex.EncodeToString(G1ToEVM(p)))
+	}
+}
+
+func Test_HashToG1Keccak256Scheme(t *testing.T) {
+	t.Parallel()
+
+	message := []byte("abc")
+
+	scheme, err := NewScheme(Keccak256CiphersuiteID, GetDomain(), HashToG1Keccak256WithDST)
+	require.NoError(t, err)
+
+	key, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	signature, err := scheme.Sign(key, message)
+	require.NoError(t, err)
+
+	assert.True(t, scheme.Verify(signature, key.PublicKey(), message))
+	assert.False(t, signature.Verify(key.PublicKey(), message))
+
+	expected, err := HashToG1Keccak256(message)
+	require.NoError(t, err)
+
+	messagePoint, err := scheme.HashToG1(message)
+	require.NoError(t, err)
+	assert.True(t, expected.IsEqual(messagePoint))
+}
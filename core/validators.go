@@ -0,0 +1,242 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrInvalidBitmap is returned when the bitmap of a certificate does not fit the validator set
+	ErrInvalidBitmap = errors.New("invalid signers bitmap")
+	// ErrQuorumNotReached is returned when the signers of a certificate do not have the quorum voting power
+	ErrQuorumNotReached = errors.New("quorum not reached")
+	// ErrInvalidCertificate is returned when the aggregated signature of a certificate is invalid
+	ErrInvalidCertificate = errors.New("invalid aggregated signature")
+
+	errEmptyValidatorSet   = errors.New("validator set is empty")
+	errInvalidValidator    = errors.New("validator must have a public key and voting power")
+	errDuplicateValidator  = errors.New("duplicate validator public key")
+	errVotingPowerOverflow = errors.New("total voting power overflows uint64")
+	errCertificateSize     = errors.New("certificate is shorter than its signature")
+)
+
+// Validator is a public key with its voting power
+type Validator struct {
+	PublicKey   *PublicKey
+	VotingPower uint64
+}
+
+// ValidatorSet is an ordered set of validators, the index of a validator is its bit in the bitmap of a certificate
+type ValidatorSet struct {
+	validators       []Validator
+	totalVotingPower uint64
+}
+
+// NewValidatorSet creates the set of the validators in the given order.
+// The public keys should have been checked with PublicKey.VerifyPossession, see FastAggregateVerify
+func NewValidatorSet(validators []Validator) (*ValidatorSet, error) {
+	if len(validators) == 0 {
+		return nil, errEmptyValidatorSet
+	}
+
+	set := &ValidatorSet{validators: make([]Validator, len(validators))}
+	seen := make(map[string]struct{}, len(validators))
+
+	for i, v := range validators {
+		if v.PublicKey == nil || v.PublicKey.p == nil || v.VotingPower == 0 {
+			return nil, fmt.Errorf("%w: validator %d", errInvalidValidator, i)
+		}
+
+		key := string(v.PublicKey.Marshal())
+		if _, ok := seen[key]; ok {
+			return nil, fmt.Errorf("%w: validator %d", errDuplicateValidator, i)
+		}
+
+		seen[key] = struct{}{}
+
+		if v.VotingPower > math.MaxUint64-set.totalVotingPower {
+			return nil, errVotingPowerOverflow
+		}
+
+		set.totalVotingPower += v.VotingPower
+		set.validators[i] = v
+	}
+
+	return set, nil
+}
+
+// Len returns the number of validators
+func (vs *ValidatorSet) Len() int {
+	return len(vs.validators)
+}
+
+// Validator returns the validator at the given index
+func (vs *ValidatorSet) Validator(index int) Validator {
+	return vs.validators[index]
+}
+
+// TotalVotingPower returns the sum of the voting power of the validators
+func (vs *ValidatorSet) TotalVotingPower() uint64 {
+	return vs.totalVotingPower
+}
+
+// Index returns the index of the validator with the public key, or -1 if it is not in the set
+func (vs *ValidatorSet) Index(publicKey *PublicKey) int {
+	raw := publicKey.Marshal()
+
+	for i, v := range vs.validators {
+		if bytes.Equal(v.PublicKey.Marshal(), raw) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Signers returns the public keys of the validators set in the bitmap and the sum of their voting power.
+// The bitmap must have exactly one bit per validator, rounded up to whole bytes with zero padding
+func (vs *ValidatorSet) Signers(bitmap Bitmap) ([]*PublicKey, uint64, error) {
+	indices, power, err := vs.signers(bitmap)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return vs.publicKeys(indices), power, nil
+}
+
+// signers returns the increasing indices of the validators set in the bitmap and the sum of their voting power
+func (vs *ValidatorSet) signers(bitmap Bitmap) ([]int, uint64, error) {
+	if err := vs.checkBitmap(bitmap); err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		indices []int
+		power   uint64
+	)
+
+	for i, v := range vs.validators {
+		if bitmap.IsSet(i) {
+			indices = append(indices, i)
+			power += v.VotingPower
+		}
+	}
+
+	return indices, power, nil
+}
+
+func (vs *ValidatorSet) publicKeys(indices []int) []*PublicKey {
+	keys := make([]*PublicKey, len(indices))
+	for i, index := range indices {
+		keys[i] = vs.validators[index].PublicKey
+	}
+
+	return keys
+}
+
+func (vs *ValidatorSet) checkBitmap(bitmap Bitmap) error {
+	n := len(vs.validators)
+
+	if len(bitmap) != (n+7)/8 {
+		return fmt.Errorf("%w: %d bytes for %d validators", ErrInvalidBitmap, len(bitmap), n)
+	}
+
+	if n%8 != 0 && bitmap[len(bitmap)-1]>>(n%8) != 0 {
+		return fmt.Errorf("%w: bits set beyond %d validators", ErrInvalidBitmap, n)
+	}
+
+	return nil
+}
+
+// Bitmap marks validators by their index in a ValidatorSet, bit i%8 of byte i/8 is the validator i
+type Bitmap []byte
+
+// NewBitmap creates an empty bitmap for n validators
+func NewBitmap(n int) Bitmap {
+	return make(Bitmap, (n+7)/8)
+}
+
+// Set marks the validator at the given index
+func (b Bitmap) Set(index int) {
+	b[index/8] |= 1 << (index % 8)
+}
+
+// IsSet tells whether the validator at the given index is marked
+func (b Bitmap) IsSet(index int) bool {
+	return index/8 < len(b) && b[index/8]&(1<<(index%8)) != 0
+}
+
+// AggregatedCertificate is the aggregated signature of the validators marked in the bitmap
+type AggregatedCertificate struct {
+	Sig    *Signature
+	Bitmap Bitmap
+}
+
+// Marshal encodes the certificate as the compressed signature followed by the bitmap
+func (c *AggregatedCertificate) Marshal() ([]byte, error) {
+	sig, err := c.Sig.MarshalCompressed()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(sig, c.Bitmap...), nil
+}
+
+// UnmarshalAggregatedCertificate decodes the certificate encoded by AggregatedCertificate.Marshal.
+// The signature is validated as in UnmarshalSignature, the bitmap is checked by VerifyCertificate
+func UnmarshalAggregatedCertificate(raw []byte) (*AggregatedCertificate, error) {
+	if len(raw) < G1CompressedSize {
+		return nil, errCertificateSize
+	}
+
+	sig, err := UnmarshalSignature(raw[:G1CompressedSize])
+	if err != nil {
+		return nil, err
+	}
+
+	return &AggregatedCertificate{
+		Sig:    sig,
+		Bitmap: append(Bitmap{}, raw[G1CompressedSize:]...),
+	}, nil
+}
+
+// VerifyCertificate checks the certificate of the message with the default scheme, see Scheme.VerifyCertificate
+func VerifyCertificate(set *ValidatorSet, certificate *AggregatedCertificate, message []byte, quorum uint64) error {
+	return defaultScheme.VerifyCertificate(set, certificate, message, quorum)
+}
+
+// VerifyCertificate checks that the bitmap of the certificate fits the validator set, that its signers have
+// at least the quorum voting power and that the aggregated signature of the message by the signers is valid
+func (s *Scheme) VerifyCertificate(set *ValidatorSet, certificate *AggregatedCertificate, message []byte,
+	quorum uint64) error {
+	indices, power, err := set.signers(certificate.Bitmap)
+	if err != nil {
+		return err
+	}
+
+	if err := checkQuorum(certificate, len(indices), power, quorum); err != nil {
+		return err
+	}
+
+	if !s.FastAggregateVerify(certificate.Sig, set.publicKeys(indices), message) {
+		return ErrInvalidCertificate
+	}
+
+	return nil
+}
+
+// checkQuorum checks that the signers of the certificate have at least the quorum voting power
+// and that the certificate has a signature
+func checkQuorum(certificate *AggregatedCertificate, signers int, power, quorum uint64) error {
+	if power < quorum || signers == 0 {
+		return fmt.Errorf("%w: voting power %d of %d", ErrQuorumNotReached, power, quorum)
+	}
+
+	if certificate.Sig == nil || certificate.Sig.p == nil {
+		return ErrInvalidCertificate
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidators_VerifyCertificate(t *testing.T) {
+	t.Parallel()
+
+	const n = 11
+
+	message := testGenRandomBytes(t, 32)
+	keys, set := testValidatorSet(t, n)
+	require.Equal(t, uint64(n*(n+1)/2), set.TotalVotingPower())
+
+	// the validators 3 to 10 have 3 + ... + 11 = 60 of 66
+	certificate := testCertificate(t, keys, message, 3, 4, 5, 6, 7, 8, 9, 10)
+
+	raw, err := certificate.Marshal()
+	require.NoError(t, err)
+	require.Len(t, raw, G1CompressedSize+2)
+
+	certificate, err = UnmarshalAggregatedCertificate(raw)
+	require.NoError(t, err)
+
+	signers, power, err := set.Signers(certificate.Bitmap)
+	require.NoError(t, err)
+	assert.Len(t, signers, 8)
+	assert.Equal(t, uint64(60), power)
+
+	require.NoError(t, VerifyCertificate(set, certificate, message, 44))
+	require.NoError(t, VerifyCertificate(set, certificate, message, 60))
+	assert.ErrorIs(t, VerifyCertificate(set, certificate, message, 61), ErrQuorumNotReached)
+	assert.ErrorIs(t, VerifyCertificate(set, certificate, []byte("other"), 44), ErrInvalidCertificate)
+
+	// a validator marked without its signature
+	withoutSignature := *certificate
+	withoutSignature.Bitmap = append(Bitmap{}, certificate.Bitmap...)
+	withoutSignature.Bitmap.Set(0)
+	assert.ErrorIs(t, VerifyCertificate(set, &withoutSignature, message, 44), ErrInvalidCertificate)
+
+	// the bitmap must fit the set exactly
+	for _, bitmap := range []Bitmap{{0xff}, {0xff, 0x07, 0x00}, {0xff, 0x08}} {
+		invalid := AggregatedCertificate{Sig: certificate.Sig, Bitmap: bitmap}
+		assert.ErrorIs(t, VerifyCertificate(set, &invalid, message, 44), ErrInvalidBitmap)
+	}
+
+	empty := AggregatedCertificate{Sig: certificate.Sig, Bitmap: NewBitmap(n)}
+	assert.ErrorIs(t, VerifyCertificate(set, &empty, message, 0), ErrQuorumNotReached)
+
+	assert.Equal(t, 4, set.Index(keys[4].PublicKey()))
+	assert.Equal(t, -1, set.Index(testValidatorKey(t).PublicKey()))
+
+	_, err = UnmarshalAggregatedCertificate(raw[:G1CompressedSize-1])
+	assert.ErrorIs(t, err, errCertificateSize)
+}
+
+func TestValidators_NewValidatorSet(t *testing.T) {
+	t.Parallel()
+
+	publicKey := testValidatorKey(t).PublicKey()
+
+	for _, validators := range [][]Validator{
+		nil,
+		{{PublicKey: publicKey}},
+		{{VotingPower: 1}},
+		{{PublicKey: publicKey, VotingPower: 1}, {PublicKey: publicKey, VotingPower: 2}},
+		{{PublicKey: publicKey, VotingPower: math.MaxUint64}, {PublicKey: testValidatorKey(t).PublicKey(), VotingPower: 1}},
+	} {
+		_, err := NewValidatorSet(validators)
+		assert.Error(t, err)
+	}
+}
+
+func TestValidators_Bitmap(t *testing.T) {
+	t.Parallel()
+
+	bitmap := NewBitmap(17)
+	require.Len(t, bitmap, 3)
+
+	bitmap.Set(0)
+	bitmap.Set(9)
+	bitmap.Set(16)
+
+	assert.Equal(t, Bitmap{0x01, 0x02, 0x01}, bitmap)
+	assert.True(t, bitmap.IsSet(9))
+	assert.False(t, bitmap.IsSet(8))
+	assert.False(t, bitmap.IsSet(100))
+}
+
+// testValidatorSet creates n validators, the validator i has the voting power i + 1
+func testValidatorSet(t *testing.T, n int) ([]*PrivateKey, *ValidatorSet) {
+	t.Helper()
+
+	keys := make([]*PrivateKey, n)
+	validators := make([]Validator, n)
+
+	for i := range keys {
+		keys[i] = testValidatorKey(t)
+		validators[i] = Validator{PublicKey: keys[i].PublicKey(), VotingPower: uint64(i + 1)}
+	}
+
+	set, err := NewValidatorSet(validators)
+	require.NoError(t, err)
+
+	return keys, set
+}
+
+func testCertificate(t *testing.T, keys []*PrivateKey, message []byte, signers ...int) *AggregatedCertificate {
+	t.Helper()
+
+	bitmap := NewBitmap(len(keys))
+	signatures := make([]*Signature, len(signers))
+
+	for i, signer := range signers {
+		signature, err := keys[signer].Sign(message)
+		require.NoError(t, err)
+
+		signatures[i] = signature
+		bitmap.Set(signer)
+	}
+
+	return &AggregatedCertificate{Sig: AggregateSignatures(signatures), Bitmap: bitmap}
+}
+
+func testValidatorKey(t *testing.T) *PrivateKey {
+	t.Helper()
+
+	key, err := GenerateBlsKey()
+	require.NoError(t, err)
+
+	return key
+}
@@ -0,0 +1,251 @@
+package dkg
+
+import (
+	"fmt"
+
+	"github.com/0xPolygon/bnsnark1/core"
+)
+
+// dealer shares the constant term of its polynomial with the recipients indexed from 1 to total
+type dealer struct {
+	index        uint64
+	coefficients []core.Fr
+}
+
+// deal returns the deal to broadcast and the shares to send to every recipient
+func (d *dealer) deal(total int) (*Deal, []*Share, error) {
+	commitments := make([]core.G2, len(d.coefficients))
+	generator := core.GetG2Generator()
+
+	for i := range d.coefficients {
+		core.G2Mul(&commitments[i], generator, &d.coefficients[i])
+	}
+
+	shares := make([]*Share, total)
+
+	for i := range shares {
+		share := &Share{Dealer: d.index, Recipient: uint64(i + 1)}
+
+		if err := core.FrEvaluatePolynomial(&share.Value, d.coefficients, indexToFr(share.Recipient)); err != nil {
+			return nil, nil, err
+		}
+
+		shares[i] = share
+	}
+
+	return &Deal{Dealer: d.index, Commitments: commitments}, shares, nil
+}
+
+// justify returns the justifications for the complaints against the dealer
+func (d *dealer) justify(complaints []*Complaint) ([]*Justification, error) {
+	var justifications []*Justification
+
+	for _, complaint := range complaints {
+		if complaint.Dealer != d.index {
+			continue
+		}
+
+		justification := &Justification{Dealer: d.index, Recipient: complaint.Complainer}
+
+		err := core.FrEvaluatePolynomial(&justification.Value, d.coefficients, indexToFr(complaint.Complainer))
+		if err != nil {
+			return nil, err
+		}
+
+		justifications = append(justifications, justification)
+	}
+
+	return justifications, nil
+}
+
+// verifier tracks the deals, shares and complaints of a dealing phase from the point of view of a single recipient
+type verifier struct {
+	index      uint64
+	threshold  int
+	dealers    int
+	recipients int
+
+	// validate performs additional checks on the commitments of the dealer
+	validate func(dealer uint64, commitments []core.G2) error
+
+	commitments  map[uint64][]core.G2
+	shares       map[uint64]*core.Fr
+	complaints   map[uint64][]uint64
+	disqualified map[uint64]struct{}
+}
+
+func newVerifier(index uint64, threshold, dealers, recipients int) *verifier {
+	return &verifier{
+		index:        index,
+		threshold:    threshold,
+		dealers:      dealers,
+		recipients:   recipients,
+		commitments:  make(map[uint64][]core.G2),
+		shares:       make(map[uint64]*core.Fr),
+		complaints:   make(map[uint64][]uint64),
+		disqualified: make(map[uint64]struct{}),
+	}
+}
+
+// processDeals verifies the broadcast deals and the shares sent to the recipient.
+// Dealers without a well formed deal are disqualified. Complaints are returned
+// for dealers whose share is missing, addressed to another recipient or inconsistent with their commitments
+func (v *verifier) processDeals(deals []*Deal, shares []*Share) []*Complaint {
+	for _, deal := range deals {
+		if deal.Dealer < 1 || deal.Dealer > uint64(v.dealers) {
+			continue
+		}
+
+		if _, exists := v.commitments[deal.Dealer]; exists {
+			// equivocating dealer
+			v.disqualified[deal.Dealer] = struct{}{}
+
+			continue
+		}
+
+		if err := v.validateCommitments(deal.Dealer, deal.Commitments); err != nil {
+			v.disqualified[deal.Dealer] = struct{}{}
+
+			continue
+		}
+
+		v.commitments[deal.Dealer] = deal.Commitments
+	}
+
+	for dealer := uint64(1); dealer <= uint64(v.dealers); dealer++ {
+		if _, exists := v.commitments[dealer]; !exists {
+			v.disqualified[dealer] = struct{}{}
+		}
+	}
+
+	misaddressed := make(map[uint64]struct{})
+
+	for _, share := range shares {
+		if share.Recipient != v.index {
+			// the dealer must justify the share of the recipient
+			misaddressed[share.Dealer] = struct{}{}
+
+			continue
+		}
+
+		if _, exists := v.commitments[share.Dealer]; !exists {
+			continue
+		}
+
+		if v.verifyShare(share.Dealer, share.Recipient, &share.Value) {
+			value := share.Value
+			v.shares[share.Dealer] = &value
+		}
+	}
+
+	var complaints []*Complaint
+
+	for dealer := uint64(1); dealer <= uint64(v.dealers); dealer++ {
+		if _, exists := v.commitments[dealer]; !exists {
+			continue
+		}
+
+		_, valid := v.shares[dealer]
+		if _, exists := misaddressed[dealer]; exists || !valid {
+			delete(v.shares, dealer)
+
+			complaints = append(complaints, &Complaint{Dealer: dealer, Complainer: v.index})
+		}
+	}
+
+	return complaints
+}
+
+// processComplaints records all of the broadcast complaints
+func (v *verifier) processComplaints(complaints []*Complaint) {
+	for _, complaint := range complaints {
+		if complaint.Complainer < 1 || complaint.Complainer > uint64(v.recipients) {
+			continue
+		}
+
+		v.complaints[complaint.Dealer] = append(v.complaints[complaint.Dealer], complaint.Complainer)
+	}
+}
+
+// processJustifications verifies the broadcast justifications. Dealers which do not justify
+// every complaint against them with a share consistent with their commitments are disqualified.
+// The recipient adopts the justified shares it has complained about
+func (v *verifier) processJustifications(justifications []*Justification) {
+	justified := make(map[Complaint]*core.Fr, len(justifications))
+
+	for _, justification := range justifications {
+		complaint := Complaint{Dealer: justification.Dealer, Complainer: justification.Recipient}
+		value := justification.Value
+		justified[complaint] = &value
+	}
+
+	for dealer, complainers := range v.complaints {
+		if _, exists := v.commitments[dealer]; !exists {
+			continue
+		}
+
+		for _, complainer := range complainers {
+			value, exists := justified[Complaint{Dealer: dealer, Complainer: complainer}]
+			if !exists || !v.verifyShare(dealer, complainer, value) {
+				v.disqualified[dealer] = struct{}{}
+
+				break
+			}
+
+			if complainer == v.index {
+				v.shares[dealer] = value
+			}
+		}
+	}
+}
+
+// qualified returns the sorted indices of the dealers which are not disqualified
+func (v *verifier) qualified() []uint64 {
+	var qualified []uint64
+
+	for dealer := uint64(1); dealer <= uint64(v.dealers); dealer++ {
+		if _, exists := v.disqualified[dealer]; !exists {
+			qualified = append(qualified, dealer)
+		}
+	}
+
+	return qualified
+}
+
+func (v *verifier) validateCommitments(dealer uint64, commitments []core.G2) error {
+	if len(commitments) != v.threshold {
+		return errMalformedCommitments
+	}
+
+	for i := range commitments {
+		if err := core.ValidateG2(&commitments[i]); err != nil {
+			return fmt.Errorf("%w: %v", errMalformedCommitments, err)
+		}
+	}
+
+	if v.validate != nil {
+		return v.validate(dealer, commitments)
+	}
+
+	return nil
+}
+
+// verifyShare checks value * g2 == sum(C_k * recipient^k)
+func (v *verifier) verifyShare(dealer, recipient uint64, value *core.Fr) bool {
+	expected, actual := new(core.G2), new(core.G2)
+
+	if err := core.G2EvaluatePolynomial(expected, v.commitments[dealer], indexToFr(recipient)); err != nil {
+		return false
+	}
+
+	core.G2Mul(actual, core.GetG2Generator(), value)
+
+	return actual.IsEqual(expected)
+}
+
+func indexToFr(index uint64) *core.Fr {
+	fr := new(core.Fr)
+	fr.SetInt64(int64(index))
+
+	return fr
+}
@@ -0,0 +1,187 @@
+// Package dkg implements Joint-Feldman distributed key generation for threshold BLS keys.
+//
+// Every participant acts as a dealer of a random polynomial of degree threshold-1, broadcasts
+// commitments to its coefficients in G2 and privately sends one evaluation to every other participant.
+// Invalid or missing shares are complained about, dealers must publicly justify them and dealers which
+// fail to do so are disqualified. The group private key is the sum of the constant terms of the
+// qualified dealers and is never known to anyone.
+//
+// Joint-Feldman allows a rushing adversary to bias the distribution of the group public key,
+// which is acceptable for threshold signatures, but not for protocols relying on its uniformity
+package dkg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/bnsnark1/core"
+)
+
+var (
+	errInvalidThreshold     = errors.New("threshold must be between 1 and the total number of participants")
+	errInvalidIndex         = errors.New("participant index must be between 1 and the total number of participants")
+	errNotDealt             = errors.New("participant has not dealt yet")
+	errNotEnoughQualified   = errors.New("not enough qualified dealers")
+	errRandomCoefficient    = errors.New("error generating random coefficient")
+	errDisqualifiedDealer   = errors.New("dealer is disqualified")
+	errMalformedCommitments = errors.New("malformed commitments")
+)
+
+// Deal is broadcast by the dealer to all participants
+type Deal struct {
+	Dealer      uint64
+	Commitments []core.G2
+}
+
+// Share is sent privately by the dealer to the recipient
+type Share struct {
+	Dealer    uint64
+	Recipient uint64
+	Value     core.Fr
+}
+
+// Complaint is broadcast by the recipient of a missing or invalid share
+type Complaint struct {
+	Dealer     uint64
+	Complainer uint64
+}
+
+// Justification is broadcast by the dealer in response to a complaint and reveals the disputed share
+type Justification struct {
+	Dealer    uint64
+	Recipient uint64
+	Value     core.Fr
+}
+
+// Result is the outcome of the distributed key generation for a single participant
+type Result struct {
+	// Share is the key share of the participant
+	Share *core.KeyShare
+	// GroupKey is the public key verifying signatures recombined from threshold of shares
+	GroupKey *core.PublicKey
+	// PublicShares are the public key shares of all participants
+	PublicShares []*core.PublicKeyShare
+	// Qualified are the indices of the dealers contributing to the group key
+	Qualified []uint64
+}
+
+// Participant runs the distributed key generation. Participants are indexed from 1 to total
+type Participant struct {
+	*verifier
+
+	total  int
+	dealer *dealer
+}
+
+// NewParticipant creates the participant with the given index
+func NewParticipant(index uint64, threshold, total int) (*Participant, error) {
+	if threshold < 1 || threshold > total {
+		return nil, errInvalidThreshold
+	}
+
+	if index < 1 || index > uint64(total) {
+		return nil, errInvalidIndex
+	}
+
+	return &Participant{
+		verifier: newVerifier(index, threshold, total, total),
+		total:    total,
+	}, nil
+}
+
+// Index returns the index of the participant
+func (p *Participant) Index() uint64 {
+	return p.index
+}
+
+// Deal generates the random polynomial of the participant and returns the deal to broadcast
+// and the shares to send to every participant, including the participant itself
+func (p *Participant) Deal() (*Deal, []*Share, error) {
+	coefficients := make([]core.Fr, p.threshold)
+
+	for i := range coefficients {
+		if !coefficients[i].SetByCSPRNG() {
+			return nil, nil, errRandomCoefficient
+		}
+	}
+
+	p.dealer = &dealer{index: p.index, coefficients: coefficients}
+
+	return p.dealer.deal(p.total)
+}
+
+// ProcessDeals verifies the broadcast deals and the shares sent to the participant.
+// Dealers without a well formed deal are disqualified. Complaints are returned
+// for dealers whose share is missing, addressed to another participant or inconsistent with their commitments
+func (p *Participant) ProcessDeals(deals []*Deal, shares []*Share) ([]*Complaint, error) {
+	if p.dealer == nil {
+		return nil, errNotDealt
+	}
+
+	return p.processDeals(deals, shares), nil
+}
+
+// ProcessComplaints records all of the broadcast complaints and returns the justifications
+// the participant must broadcast for the complaints against itself
+func (p *Participant) ProcessComplaints(complaints []*Complaint) ([]*Justification, error) {
+	if p.dealer == nil {
+		return nil, errNotDealt
+	}
+
+	p.processComplaints(complaints)
+
+	return p.dealer.justify(complaints)
+}
+
+// ProcessJustifications verifies the broadcast justifications. Dealers which do not justify
+// every complaint against them with a share consistent with their commitments are disqualified.
+// The participant adopts the justified shares it has complained about
+func (p *Participant) ProcessJustifications(justifications []*Justification) {
+	p.processJustifications(justifications)
+}
+
+// Finalize combines the shares and commitments of the qualified dealers
+// into the key share of the participant and the group public key
+func (p *Participant) Finalize() (*Result, error) {
+	qualified := p.qualified()
+
+	if len(qualified) < p.threshold {
+		return nil, fmt.Errorf("%w: %d of %d", errNotEnoughQualified, len(qualified), p.threshold)
+	}
+
+	secret := new(core.Fr)
+	groupCommitments := make([]core.G2, p.threshold)
+
+	for _, dealer := range qualified {
+		share, exists := p.shares[dealer]
+		if !exists {
+			return nil, fmt.Errorf("%w: %d has no valid share", errDisqualifiedDealer, dealer)
+		}
+
+		core.FrAdd(secret, secret, share)
+
+		for i, commitment := range p.commitments[dealer] {
+			core.G2Add(&groupCommitments[i], &groupCommitments[i], &commitment)
+		}
+	}
+
+	publicShares := make([]*core.PublicKeyShare, p.total)
+
+	for i := range publicShares {
+		index := uint64(i + 1)
+		g2 := new(core.G2)
+
+		if err := core.G2EvaluatePolynomial(g2, groupCommitments, indexToFr(index)); err != nil {
+			return nil, err
+		}
+
+		publicShares[i] = &core.PublicKeyShare{Index: index, Key: core.NewPublicKey(g2)}
+	}
+
+	return &Result{
+		Share:        &core.KeyShare{Index: p.index, Key: core.NewPrivateKey(secret)},
+		GroupKey:     core.NewPublicKey(&groupCommitments[0]),
+		PublicShares: publicShares,
+		Qualified:    qualified,
+	}, nil
+}
@@ -0,0 +1,225 @@
+package dkg
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/bnsnark1/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testNetwork simulates the broadcast and private channels between in-process participants
+type testNetwork struct {
+	participants []*Participant
+	deals        []*Deal
+	shares       map[uint64][]*Share
+}
+
+func newTestNetwork(t *testing.T, threshold, total int) *testNetwork {
+	t.Helper()
+
+	network := &testNetwork{shares: make(map[uint64][]*Share)}
+
+	for i := 1; i <= total; i++ {
+		participant, err := NewParticipant(uint64(i), threshold, total)
+		require.NoError(t, err)
+
+		network.participants = append(network.participants, participant)
+	}
+
+	return network
+}
+
+func (n *testNetwork) deal(t *testing.T, tamper func(dealer uint64, share *Share)) {
+	t.Helper()
+
+	for _, participant := range n.participants {
+		deal, shares, err := participant.Deal()
+		require.NoError(t, err)
+
+		n.deals = append(n.deals, deal)
+
+		for _, share := range shares {
+			if tamper != nil {
+				tamper(participant.Index(), share)
+			}
+
+			n.shares[share.Recipient] = append(n.shares[share.Recipient], share)
+		}
+	}
+}
+
+func (n *testNetwork) run(t *testing.T, withhold map[Complaint]struct{}) []*Result {
+	t.Helper()
+
+	var complaints []*Complaint
+
+	for _, participant := range n.participants {
+		c, err := participant.ProcessDeals(n.deals, n.shares[participant.Index()])
+		require.NoError(t, err)
+
+		complaints = append(complaints, c...)
+	}
+
+	var justifications []*Justification
+
+	for _, participant := range n.participants {
+		j, err := participant.ProcessComplaints(complaints)
+		require.NoError(t, err)
+
+		for _, justification := range j {
+			complaint := Complaint{Dealer: justification.Dealer, Complainer: justification.Recipient}
+			if _, exists := withhold[complaint]; !exists {
+				justifications = append(justifications, justification)
+			}
+		}
+	}
+
+	results := make([]*Result, len(n.participants))
+
+	for i, participant := range n.participants {
+		participant.ProcessJustifications(justifications)
+
+		result, err := participant.Finalize()
+		require.NoError(t, err)
+
+		results[i] = result
+	}
+
+	return results
+}
+
+func testCheckResults(t *testing.T, results []*Result, threshold int, qualified []uint64) {
+	t.Helper()
+
+	message := []byte("dkg test message")
+	groupKey := results[0].GroupKey
+
+	signatureShares := make([]*core.SignatureShare, len(results))
+
+	for i, result := range results {
+		assert.Equal(t, qualified, result.Qualified)
+		assert.Equal(t, groupKey.Marshal(), result.GroupKey.Marshal())
+
+		for j, publicShare := range result.PublicShares {
+			assert.Equal(t, results[0].PublicShares[j].Key.Marshal(), publicShare.Key.Marshal())
+		}
+
+		assert.Equal(t, result.Share.PublicKey().Key.Marshal(), result.PublicShares[i].Key.Marshal())
+
+		share, err := result.Share.Sign(message)
+		require.NoError(t, err)
+
+		signatureShares[i] = share
+	}
+
+	recoveredKey, err := core.RecoverPublicKey(results[0].PublicShares[:threshold])
+	require.NoError(t, err)
+	assert.Equal(t, groupKey.Marshal(), recoveredKey.Marshal())
+
+	signature, err := core.CombineSignatures(
+		results[0].PublicShares, signatureShares[len(results)-threshold:], message, threshold)
+	require.NoError(t, err)
+
+	assert.True(t, signature.Verify(groupKey, message))
+}
+
+func TestDKG_Honest(t *testing.T) {
+	t.Parallel()
+
+	const (
+		threshold = 3
+		total     = 5
+	)
+
+	network := newTestNetwork(t, threshold, total)
+	network.deal(t, nil)
+
+	results := network.run(t, nil)
+
+	testCheckResults(t, results, threshold, []uint64{1, 2, 3, 4, 5})
+}
+
+func TestDKG_ComplaintsAndDisqualification(t *testing.T) {
+	t.Parallel()
+
+	const (
+		threshold = 3
+		total     = 6
+	)
+
+	network := newTestNetwork(t, threshold, total)
+
+	// dealer 2 sends a bad share to participant 4, but justifies it
+	// dealer 3 sends a bad share to participant 1 and does not justify it
+	network.deal(t, func(dealer uint64, share *Share) {
+		if (dealer == 2 && share.Recipient == 4) || (dealer == 3 && share.Recipient == 1) {
+			share.Value.SetInt64(1)
+		}
+	})
+
+	// dealer 5 broadcasts malformed commitments
+	network.deals[4].Commitments = network.deals[4].Commitments[1:]
+
+	// dealer 6 never deals
+	network.deals = network.deals[:5]
+
+	results := network.run(t, map[Complaint]struct{}{{Dealer: 3, Complainer: 1}: {}})
+
+	testCheckResults(t, results, threshold, []uint64{1, 2, 4})
+}
+
+func TestDKG_MisaddressedShare(t *testing.T) {
+	t.Parallel()
+
+	const (
+		threshold = 2
+		total     = 4
+	)
+
+	network := newTestNetwork(t, threshold, total)
+	network.deal(t, nil)
+
+	// participant 3 receives a share of dealer 2 addressed to participant 4 instead of its own,
+	// which does not stop it from processing the other deals
+	shares := network.shares[3]
+	for i, share := range shares {
+		if share.Dealer == 2 {
+			misaddressed := *network.shares[4][1]
+			shares[i] = &misaddressed
+		}
+	}
+
+	complaints, err := network.participants[2].ProcessDeals(network.deals, shares)
+	require.NoError(t, err)
+	assert.Equal(t, []*Complaint{{Dealer: 2, Complainer: 3}}, complaints)
+
+	network = newTestNetwork(t, threshold, total)
+	network.deal(t, nil)
+	network.shares[3] = append(network.shares[3], network.shares[4][1])
+
+	// dealer 2 justifies the share of participant 3 and stays qualified
+	results := network.run(t, nil)
+
+	testCheckResults(t, results, threshold, []uint64{1, 2, 3, 4})
+}
+
+func TestDKG_NotEnoughQualified(t *testing.T) {
+	t.Parallel()
+
+	network := newTestNetwork(t, 3, 3)
+	network.deal(t, nil)
+	network.deals = network.deals[:2]
+
+	_, err := network.participants[0].ProcessDeals(network.deals, network.shares[1])
+	require.NoError(t, err)
+
+	_, err = network.participants[0].Finalize()
+	assert.ErrorIs(t, err, errNotEnoughQualified)
+
+	_, err = NewParticipant(0, 1, 1)
+	assert.ErrorIs(t, err, errInvalidIndex)
+
+	_, err = NewParticipant(1, 2, 1)
+	assert.ErrorIs(t, err, errInvalidThreshold)
+}
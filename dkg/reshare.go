@@ -0,0 +1,214 @@
+package dkg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/bnsnark1/core"
+)
+
+// Resharing hands a threshold key from an old committee (t-of-n) to a new committee (t'-of-n')
+// without reconstructing the group private key. Every old share holder deals its share s_i with
+// a random polynomial g_i of degree t'-1 where g_i(0) = s_i and commits to it in G2, so the new
+// committee checks that C_i0 equals the old public key share of the dealer. The new share of
+// the participant j is sum(l_i * g_i(j)) over the qualified dealers, where l_i are the Lagrange
+// coefficients at zero for the old indices. The group public key is unchanged, while the new
+// shares lie on an unrelated polynomial, so the old shares become useless.
+// Resharing to the same committee refreshes the shares proactively
+
+var (
+	errUnknownOldShare     = errors.New("dealer has no public key share in the old committee")
+	errOldShareMismatch    = errors.New("commitment does not match the old public key share")
+	errGroupKeyMismatch    = errors.New("reshared group key does not match the old group key")
+	errInvalidOldThreshold = errors.New("old threshold must be between 1 and the number of old public key shares")
+)
+
+// ReshareDealer is a member of the old committee dealing its key share to the new committee
+type ReshareDealer struct {
+	dealer *dealer
+	total  int
+}
+
+// NewReshareDealer creates the dealer of the key share for the new committee of total participants
+func NewReshareDealer(share *core.KeyShare, threshold, total int) (*ReshareDealer, error) {
+	if threshold < 1 || threshold > total {
+		return nil, errInvalidThreshold
+	}
+
+	if share.Index < 1 {
+		return nil, errInvalidIndex
+	}
+
+	coefficients := make([]core.Fr, threshold)
+	coefficients[0] = *share.Key.Scalar()
+
+	for i := 1; i < threshold; i++ {
+		if !coefficients[i].SetByCSPRNG() {
+			return nil, errRandomCoefficient
+		}
+	}
+
+	return &ReshareDealer{
+		dealer: &dealer{index: share.Index, coefficients: coefficients},
+		total:  total,
+	}, nil
+}
+
+// Deal returns the deal to broadcast to the new committee and the shares to send to each of its participants
+func (d *ReshareDealer) Deal() (*Deal, []*Share, error) {
+	return d.dealer.deal(d.total)
+}
+
+// ProcessComplaints returns the justifications the dealer must broadcast for the complaints against itself
+func (d *ReshareDealer) ProcessComplaints(complaints []*Complaint) ([]*Justification, error) {
+	return d.dealer.justify(complaints)
+}
+
+// ReshareParticipant is a member of the new committee receiving the reshared key
+type ReshareParticipant struct {
+	*verifier
+
+	total        int
+	oldThreshold int
+	groupKey     *core.PublicKey
+}
+
+// NewReshareParticipant creates the participant with the given index in the new committee.
+// The old public key shares and the group key are known to everyone from the previous epoch
+func NewReshareParticipant(
+	index uint64, threshold, total int,
+	oldThreshold int, oldPublicShares []*core.PublicKeyShare, groupKey *core.PublicKey,
+) (*ReshareParticipant, error) {
+	if threshold < 1 || threshold > total {
+		return nil, errInvalidThreshold
+	}
+
+	if index < 1 || index > uint64(total) {
+		return nil, errInvalidIndex
+	}
+
+	if oldThreshold < 1 || oldThreshold > len(oldPublicShares) {
+		return nil, errInvalidOldThreshold
+	}
+
+	oldKeys := make(map[uint64][]byte, len(oldPublicShares))
+	oldTotal := 0
+
+	for _, share := range oldPublicShares {
+		oldKeys[share.Index] = share.Key.Marshal()
+
+		if int(share.Index) > oldTotal {
+			oldTotal = int(share.Index)
+		}
+	}
+
+	v := newVerifier(index, threshold, oldTotal, total)
+	v.validate = func(dealer uint64, commitments []core.G2) error {
+		oldKey, exists := oldKeys[dealer]
+		if !exists {
+			return errUnknownOldShare
+		}
+
+		if string(core.NewPublicKey(&commitments[0]).Marshal()) != string(oldKey) {
+			return errOldShareMismatch
+		}
+
+		return nil
+	}
+
+	return &ReshareParticipant{
+		verifier:     v,
+		total:        total,
+		oldThreshold: oldThreshold,
+		groupKey:     groupKey,
+	}, nil
+}
+
+// Index returns the index of the participant in the new committee
+func (p *ReshareParticipant) Index() uint64 {
+	return p.index
+}
+
+// ProcessDeals verifies the deals broadcast by the old committee and the shares sent to the participant.
+// Complaints are returned for dealers whose share is missing, addressed to another participant
+// or inconsistent with their commitments
+func (p *ReshareParticipant) ProcessDeals(deals []*Deal, shares []*Share) ([]*Complaint, error) {
+	return p.processDeals(deals, shares), nil
+}
+
+// ProcessComplaints records all of the complaints broadcast by the new committee
+func (p *ReshareParticipant) ProcessComplaints(complaints []*Complaint) {
+	p.processComplaints(complaints)
+}
+
+// ProcessJustifications verifies the justifications broadcast by the old committee
+// and disqualifies the dealers which fail to justify the complaints against them
+func (p *ReshareParticipant) ProcessJustifications(justifications []*Justification) {
+	p.processJustifications(justifications)
+}
+
+// Finalize interpolates the shares and commitments of the qualified dealers into the new key share
+// of the participant and the public key shares of the new committee
+func (p *ReshareParticipant) Finalize() (*Result, error) {
+	qualified := p.qualified()
+
+	if len(qualified) < p.oldThreshold {
+		return nil, fmt.Errorf("%w: %d of %d", errNotEnoughQualified, len(qualified), p.oldThreshold)
+	}
+
+	xVec := make([]core.Fr, len(qualified))
+	shares := make([]core.Fr, len(qualified))
+	constants := make([]core.G2, len(qualified))
+
+	for i, dealer := range qualified {
+		share, exists := p.shares[dealer]
+		if !exists {
+			return nil, fmt.Errorf("%w: %d has no valid share", errDisqualifiedDealer, dealer)
+		}
+
+		xVec[i] = *indexToFr(dealer)
+		shares[i] = *share
+		constants[i] = p.commitments[dealer][0]
+	}
+
+	secret := new(core.Fr)
+	if err := core.FrLagrangeInterpolation(secret, xVec, shares); err != nil {
+		return nil, err
+	}
+
+	groupKey := new(core.G2)
+	if err := core.G2LagrangeInterpolation(groupKey, xVec, constants); err != nil {
+		return nil, err
+	}
+
+	if string(core.NewPublicKey(groupKey).Marshal()) != string(p.groupKey.Marshal()) {
+		return nil, errGroupKeyMismatch
+	}
+
+	publicShares := make([]*core.PublicKeyShare, p.total)
+
+	for i := range publicShares {
+		index := uint64(i + 1)
+		evaluations := make([]core.G2, len(qualified))
+
+		for j, dealer := range qualified {
+			if err := core.G2EvaluatePolynomial(&evaluations[j], p.commitments[dealer], indexToFr(index)); err != nil {
+				return nil, err
+			}
+		}
+
+		g2 := new(core.G2)
+		if err := core.G2LagrangeInterpolation(g2, xVec, evaluations); err != nil {
+			return nil, err
+		}
+
+		publicShares[i] = &core.PublicKeyShare{Index: index, Key: core.NewPublicKey(g2)}
+	}
+
+	return &Result{
+		Share:        &core.KeyShare{Index: p.index, Key: core.NewPrivateKey(secret)},
+		GroupKey:     p.groupKey,
+		PublicShares: publicShares,
+		Qualified:    qualified,
+	}, nil
+}
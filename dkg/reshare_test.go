@@ -0,0 +1,149 @@
+package dkg
+
+import (
+	"testing"
+
+	"github.com/0xPolygon/bnsnark1/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReshare(t *testing.T) {
+	t.Parallel()
+
+	const (
+		oldThreshold = 3
+		oldTotal     = 5
+		threshold    = 4
+		total        = 7
+	)
+
+	groupKey, err := core.GenerateBlsKey()
+	require.NoError(t, err)
+
+	oldShares, err := core.SplitPrivateKey(groupKey, oldThreshold, oldTotal)
+	require.NoError(t, err)
+
+	oldPublicShares := core.CollectPublicKeyShares(oldShares)
+
+	participants := make([]*ReshareParticipant, total)
+
+	for i := range participants {
+		participants[i], err = NewReshareParticipant(
+			uint64(i+1), threshold, total, oldThreshold, oldPublicShares, groupKey.PublicKey())
+		require.NoError(t, err)
+	}
+
+	var (
+		dealers []*ReshareDealer
+		deals   []*Deal
+		shares  = make(map[uint64][]*Share)
+	)
+
+	// old share holder 5 sends a bad share to new participant 2 and does not justify it
+	// and an outsider impersonates old share holder 3 with an unrelated key
+	outsider, err := core.GenerateBlsKey()
+	require.NoError(t, err)
+
+	for _, keyShare := range []*core.KeyShare{
+		oldShares[0], oldShares[1], oldShares[3], oldShares[4], {Index: 3, Key: outsider},
+	} {
+		dealer, err := NewReshareDealer(keyShare, threshold, total)
+		require.NoError(t, err)
+
+		deal, dealerShares, err := dealer.Deal()
+		require.NoError(t, err)
+
+		dealers = append(dealers, dealer)
+		deals = append(deals, deal)
+
+		for _, share := range dealerShares {
+			if keyShare.Index == 5 && share.Recipient == 2 {
+				share.Value.SetInt64(1)
+			}
+
+			shares[share.Recipient] = append(shares[share.Recipient], share)
+		}
+	}
+
+	var complaints []*Complaint
+
+	for _, participant := range participants {
+		c, err := participant.ProcessDeals(deals, shares[participant.Index()])
+		require.NoError(t, err)
+
+		complaints = append(complaints, c...)
+	}
+
+	var justifications []*Justification
+
+	for _, dealer := range dealers {
+		j, err := dealer.ProcessComplaints(complaints)
+		require.NoError(t, err)
+
+		for _, justification := range j {
+			if justification.Dealer != 5 {
+				justifications = append(justifications, justification)
+			}
+		}
+	}
+
+	results := make([]*Result, total)
+	newShares := make([]*core.KeyShare, total)
+
+	for i, participant := range participants {
+		participant.ProcessComplaints(complaints)
+		participant.ProcessJustifications(justifications)
+
+		results[i], err = participant.Finalize()
+		require.NoError(t, err)
+
+		newShares[i] = results[i].Share
+	}
+
+	testCheckResults(t, results, threshold, []uint64{1, 2, 4})
+	assert.Equal(t, groupKey.PublicKey().Marshal(), results[0].GroupKey.Marshal())
+
+	recovered, err := core.RecoverPrivateKey(newShares[2:6])
+	require.NoError(t, err)
+	assert.True(t, recovered.Scalar().IsEqual(groupKey.Scalar()))
+
+	// old and new shares can not be combined
+	recovered, err = core.RecoverPrivateKey([]*core.KeyShare{oldShares[0], oldShares[1], newShares[2], newShares[3]})
+	require.NoError(t, err)
+	assert.False(t, recovered.Scalar().IsEqual(groupKey.Scalar()))
+
+	// three new shares are not enough anymore
+	recovered, err = core.RecoverPrivateKey(newShares[:3])
+	require.NoError(t, err)
+	assert.False(t, recovered.Scalar().IsEqual(groupKey.Scalar()))
+}
+
+func TestReshare_NotEnoughQualified(t *testing.T) {
+	t.Parallel()
+
+	groupKey, err := core.GenerateBlsKey()
+	require.NoError(t, err)
+
+	oldShares, err := core.SplitPrivateKey(groupKey, 2, 3)
+	require.NoError(t, err)
+
+	participant, err := NewReshareParticipant(
+		1, 1, 1, 2, core.CollectPublicKeyShares(oldShares), groupKey.PublicKey())
+	require.NoError(t, err)
+
+	dealer, err := NewReshareDealer(oldShares[0], 1, 1)
+	require.NoError(t, err)
+
+	deal, shares, err := dealer.Deal()
+	require.NoError(t, err)
+
+	_, err = participant.ProcessDeals([]*Deal{deal}, shares)
+	require.NoError(t, err)
+
+	_, err = participant.Finalize()
+	assert.ErrorIs(t, err, errNotEnoughQualified)
+
+	_, err = NewReshareParticipant(1, 1, 1, 4, core.CollectPublicKeyShares(oldShares), groupKey.PublicKey())
+	assert.ErrorIs(t, err, errInvalidOldThreshold)
+}
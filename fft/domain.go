@@ -0,0 +1,233 @@
+// Package fft implements the number-theoretic transform over Fr and the polynomial arithmetic built on it.
+//
+// The order of Fr minus one is divisible by 2^28, so Fr has roots of unity of every power of two order up to
+// 2^28 and polynomials of up to 2^28 coefficients can be evaluated and interpolated in O(n log n).
+// Polynomials are slices of coefficients from the constant term up, evaluations are in the natural order
+// of the domain: p(1), p(w), p(w^2), ...
+package fft
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/0xPolygon/bnsnark1/core"
+)
+
+const (
+	// MaxOrder is the log2 of the largest domain, the 2-adicity of Fr
+	MaxOrder = 28
+
+	// multiplicativeGenerator generates the multiplicative group of Fr, it is the shift of the cosets
+	multiplicativeGenerator = 5
+)
+
+var (
+	errDomainSize    = fmt.Errorf("domain size must be a power of two between 1 and 2^%d", MaxOrder)
+	errInputTooLarge = errors.New("input is larger than the domain")
+)
+
+// Domain is the multiplicative subgroup of Fr of order Size, generated by the primitive root of unity Generator
+type Domain struct {
+	Size         uint64
+	Generator    core.Fr
+	GeneratorInv core.Fr
+	SizeInv      core.Fr
+	// CosetShift generates the coset CosetShift * <Generator> used by the coset transforms
+	CosetShift    core.Fr
+	CosetShiftInv core.Fr
+
+	logSize     int
+	twiddles    []core.Fr
+	twiddlesInv []core.Fr
+}
+
+// NewDomain creates the domain of the given size, which must be a power of two.
+// It precomputes Size/2 powers of the root of unity and of its inverse
+func NewDomain(size uint64) (*Domain, error) {
+	if size == 0 || size&(size-1) != 0 || size > 1<<MaxOrder {
+		return nil, errDomainSize
+	}
+
+	d := &Domain{Size: size, logSize: bits.TrailingZeros64(size)}
+
+	d.Generator = rootOfUnity(d.logSize)
+	core.FrInv(&d.GeneratorInv, &d.Generator)
+
+	d.SizeInv.SetInt64(int64(size))
+	core.FrInv(&d.SizeInv, &d.SizeInv)
+
+	d.CosetShift.SetInt64(multiplicativeGenerator)
+	core.FrInv(&d.CosetShiftInv, &d.CosetShift)
+
+	d.twiddles = powers(&d.Generator, size/2)
+	d.twiddlesInv = powers(&d.GeneratorInv, size/2)
+
+	return d, nil
+}
+
+// Element returns the i-th element of the domain, Generator^i
+func (d *Domain) Element(i uint64) *core.Fr {
+	return frExp(&d.Generator, new(big.Int).SetUint64(i%d.Size))
+}
+
+// FFT replaces the coefficients of the polynomial by its evaluations over the domain.
+// The length of a must be the size of the domain
+func (d *Domain) FFT(a []core.Fr) error {
+	if uint64(len(a)) != d.Size {
+		return fmt.Errorf("%w: got %d elements for a domain of %d", errDomainSize, len(a), d.Size)
+	}
+
+	d.transform(a, d.twiddles)
+
+	return nil
+}
+
+// InverseFFT replaces the evaluations over the domain by the coefficients of the polynomial
+func (d *Domain) InverseFFT(a []core.Fr) error {
+	if uint64(len(a)) != d.Size {
+		return fmt.Errorf("%w: got %d elements for a domain of %d", errDomainSize, len(a), d.Size)
+	}
+
+	d.transform(a, d.twiddlesInv)
+
+	for i := range a {
+		core.FrMul(&a[i], &a[i], &d.SizeInv)
+	}
+
+	return nil
+}
+
+// CosetFFT replaces the coefficients of the polynomial by its evaluations over the coset
+// CosetShift * domain, which does not intersect the domain
+func (d *Domain) CosetFFT(a []core.Fr) error {
+	if uint64(len(a)) != d.Size {
+		return fmt.Errorf("%w: got %d elements for a domain of %d", errDomainSize, len(a), d.Size)
+	}
+
+	scaleByPowers(a, &d.CosetShift)
+	d.transform(a, d.twiddles)
+
+	return nil
+}
+
+// CosetInverseFFT replaces the evaluations over the coset CosetShift * domain by the coefficients
+func (d *Domain) CosetInverseFFT(a []core.Fr) error {
+	if err := d.InverseFFT(a); err != nil {
+		return err
+	}
+
+	scaleByPowers(a, &d.CosetShiftInv)
+
+	return nil
+}
+
+// Evaluations returns the evaluations over the domain of the polynomial of at most Size coefficients
+func (d *Domain) Evaluations(coefficients []core.Fr) ([]core.Fr, error) {
+	if uint64(len(coefficients)) > d.Size {
+		return nil, errInputTooLarge
+	}
+
+	res := make([]core.Fr, d.Size)
+	copy(res, coefficients)
+
+	return res, d.FFT(res)
+}
+
+// Coefficients returns the coefficients of the polynomial of degree below Size with the evaluations over the domain
+func (d *Domain) Coefficients(evaluations []core.Fr) ([]core.Fr, error) {
+	res := append([]core.Fr{}, evaluations...)
+
+	return res, d.InverseFFT(res)
+}
+
+// transform is the iterative radix-2 Cooley-Tukey transform, the input is permuted into bit reversed order
+// and the butterflies of every stage use the twiddles with the stride of the stage
+func (d *Domain) transform(a []core.Fr, twiddles []core.Fr) {
+	n := uint64(len(a))
+	if n == 1 {
+		return
+	}
+
+	shift := 64 - d.logSize
+
+	for i := uint64(0); i < n; i++ {
+		if j := bits.Reverse64(i) >> shift; i < j {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+
+	var t core.Fr
+
+	for half := uint64(1); half < n; half <<= 1 {
+		stride := n / (2 * half)
+
+		for start := uint64(0); start < n; start += 2 * half {
+			for j := uint64(0); j < half; j++ {
+				u, v := &a[start+j], &a[start+j+half]
+
+				core.FrMul(&t, v, &twiddles[j*stride])
+				core.FrSub(v, u, &t)
+				core.FrAdd(u, u, &t)
+			}
+		}
+	}
+}
+
+// rootOfUnity returns the primitive root of unity of order 2^logSize
+func rootOfUnity(logSize int) core.Fr {
+	order, _ := new(big.Int).SetString(core.GetCurveOrder(), 10)
+
+	// g^((r - 1) / 2^logSize) for the generator g of the multiplicative group
+	exp := new(big.Int).Sub(order, big.NewInt(1))
+	exp.Rsh(exp, uint(logSize))
+
+	g := new(core.Fr)
+	g.SetInt64(multiplicativeGenerator)
+
+	return *frExp(g, exp)
+}
+
+// powers returns 1, x, x^2, ..., x^(n-1)
+func powers(x *core.Fr, n uint64) []core.Fr {
+	res := make([]core.Fr, n)
+	if n == 0 {
+		return res
+	}
+
+	res[0].SetInt64(1)
+
+	for i := uint64(1); i < n; i++ {
+		core.FrMul(&res[i], &res[i-1], x)
+	}
+
+	return res
+}
+
+// scaleByPowers multiplies a[i] by x^i
+func scaleByPowers(a []core.Fr, x *core.Fr) {
+	var power core.Fr
+
+	power.SetInt64(1)
+
+	for i := range a {
+		core.FrMul(&a[i], &a[i], &power)
+		core.FrMul(&power, &power, x)
+	}
+}
+
+func frExp(x *core.Fr, e *big.Int) *core.Fr {
+	res := new(core.Fr)
+	res.SetInt64(1)
+
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		core.FrSqr(res, res)
+
+		if e.Bit(i) == 1 {
+			core.FrMul(res, res, x)
+		}
+	}
+
+	return res
+}
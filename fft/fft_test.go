@@ -0,0 +1,232 @@
+package fft
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygon/bnsnark1/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomain_RootsOfUnity(t *testing.T) {
+	t.Parallel()
+
+	// the coset shift is a quadratic non-residue, so the largest root of unity is primitive
+	order, _ := new(big.Int).SetString(core.GetCurveOrder(), 10)
+	half := new(big.Int).Rsh(new(big.Int).Sub(order, big.NewInt(1)), 1)
+
+	shift := new(core.Fr)
+	shift.SetInt64(multiplicativeGenerator)
+
+	minusOne := new(core.Fr)
+	minusOne.SetInt64(-1)
+	assert.True(t, frExp(shift, half).IsEqual(minusOne))
+
+	// the domain of the largest order is too large to allocate in a test
+	root := rootOfUnity(MaxOrder)
+	assert.True(t, frExp(&root, new(big.Int).Lsh(big.NewInt(1), MaxOrder-1)).IsEqual(minusOne))
+
+	for _, logSize := range []int{0, 1, 5, 12} {
+		d, err := NewDomain(1 << logSize)
+		require.NoError(t, err)
+
+		// w^(n/2) = -1 and w^n = 1
+		if logSize > 0 {
+			assert.True(t, d.Element(d.Size/2).IsEqual(minusOne))
+		}
+
+		assert.True(t, frExp(&d.Generator, new(big.Int).SetUint64(d.Size)).IsOne())
+		assert.True(t, d.EvaluateVanishing(&d.Generator).IsZero())
+	}
+
+	for _, size := range []uint64{0, 3, 1 << (MaxOrder + 1)} {
+		_, err := NewDomain(size)
+		assert.ErrorIs(t, err, errDomainSize)
+	}
+}
+
+func TestDomain_FFT(t *testing.T) {
+	t.Parallel()
+
+	for _, size := range []uint64{1, 2, 8, 64} {
+		d, err := NewDomain(size)
+		require.NoError(t, err)
+
+		coefficients := randomPolynomial(t, int(size))
+
+		evaluations, err := d.Evaluations(coefficients)
+		require.NoError(t, err)
+
+		coset := append([]core.Fr{}, coefficients...)
+		require.NoError(t, d.CosetFFT(coset))
+
+		for i := uint64(0); i < size; i++ {
+			x := d.Element(i)
+			assert.True(t, evaluate(coefficients, x).IsEqual(&evaluations[i]))
+
+			core.FrMul(x, x, &d.CosetShift)
+			assert.True(t, evaluate(coefficients, x).IsEqual(&coset[i]))
+		}
+
+		back, err := d.Coefficients(evaluations)
+		require.NoError(t, err)
+		assertEqualPolynomials(t, coefficients, back)
+
+		require.NoError(t, d.CosetInverseFFT(coset))
+		assertEqualPolynomials(t, coefficients, coset)
+
+		assert.ErrorIs(t, d.FFT(make([]core.Fr, size+1)), errDomainSize)
+
+		_, err = d.Evaluations(make([]core.Fr, size+1))
+		assert.ErrorIs(t, err, errInputTooLarge)
+	}
+}
+
+func TestPolynomial_MultiplyDivide(t *testing.T) {
+	t.Parallel()
+
+	a := randomPolynomial(t, 37)
+	b := randomPolynomial(t, 20)
+
+	product, err := Multiply(a, b)
+	require.NoError(t, err)
+	require.Len(t, product, 56)
+
+	x := randomFr(t)
+
+	expected := evaluate(a, x)
+	core.FrMul(expected, expected, evaluate(b, x))
+	assert.True(t, evaluate(product, x).IsEqual(expected))
+
+	quotient, remainder, err := Divide(product, b)
+	require.NoError(t, err)
+	assertEqualPolynomials(t, a, quotient)
+	assert.Empty(t, remainder)
+
+	// a = quotient * b + remainder
+	quotient, remainder, err = Divide(a, b)
+	require.NoError(t, err)
+	require.Len(t, quotient, 18)
+	require.Less(t, len(remainder), len(b))
+
+	expected = evaluate(quotient, x)
+	core.FrMul(expected, expected, evaluate(b, x))
+	core.FrAdd(expected, expected, evaluate(remainder, x))
+	assert.True(t, evaluate(a, x).IsEqual(expected))
+
+	_, _, err = Divide(a, make([]core.Fr, 3))
+	assert.ErrorIs(t, err, errDivisionByZero)
+
+	product, err = Multiply(a, nil)
+	require.NoError(t, err)
+	assert.Empty(t, product)
+}
+
+func TestDomain_DivideByVanishing(t *testing.T) {
+	t.Parallel()
+
+	d, err := NewDomain(16)
+	require.NoError(t, err)
+
+	vanishing := d.VanishingPolynomial()
+	assert.True(t, evaluate(vanishing, d.Element(3)).IsZero())
+
+	a := randomPolynomial(t, 40)
+
+	quotient, remainder := d.DivideByVanishing(a)
+
+	expectedQuotient, expectedRemainder, err := Divide(a, vanishing)
+	require.NoError(t, err)
+	assertEqualPolynomials(t, expectedQuotient, quotient)
+	assertEqualPolynomials(t, expectedRemainder, remainder)
+
+	// a multiple of the vanishing polynomial is divided exactly
+	product, err := Multiply(a, vanishing)
+	require.NoError(t, err)
+
+	quotient, remainder = d.DivideByVanishing(product)
+	assertEqualPolynomials(t, a, quotient)
+	assert.Empty(t, remainder)
+
+	x := randomFr(t)
+	assert.True(t, evaluate(vanishing, x).IsEqual(d.EvaluateVanishing(x)))
+}
+
+func BenchmarkFFT(b *testing.B) {
+	for _, logSize := range []int{10, 14, 16, 18, 20} {
+		d, err := NewDomain(1 << logSize)
+		require.NoError(b, err)
+
+		a := make([]core.Fr, d.Size)
+		for i := range a {
+			a[i].SetInt64(int64(i))
+		}
+
+		b.Run(fmt.Sprintf("2^%d", logSize), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = d.FFT(a)
+			}
+		})
+	}
+}
+
+func BenchmarkMultiply(b *testing.B) {
+	for _, logSize := range []int{10, 14, 19} {
+		a := make([]core.Fr, 1<<logSize)
+		for i := range a {
+			a[i].SetInt64(int64(i))
+		}
+
+		b.Run(fmt.Sprintf("2^%d", logSize), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = Multiply(a, a)
+			}
+		})
+	}
+}
+
+func evaluate(p []core.Fr, x *core.Fr) *core.Fr {
+	y := new(core.Fr)
+	if err := core.FrEvaluatePolynomial(y, p, x); err != nil {
+		panic(err)
+	}
+
+	return y
+}
+
+func assertEqualPolynomials(t *testing.T, expected, actual []core.Fr) {
+	t.Helper()
+
+	expected, actual = trim(expected), trim(actual)
+
+	require.Equal(t, len(expected), len(actual))
+
+	for i := range expected {
+		assert.True(t, expected[i].IsEqual(&actual[i]), "coefficient %d", i)
+	}
+}
+
+func randomFr(t *testing.T) *core.Fr {
+	t.Helper()
+
+	fr := new(core.Fr)
+	require.True(t, fr.SetByCSPRNG())
+
+	return fr
+}
+
+func randomPolynomial(t *testing.T, n int) []core.Fr {
+	t.Helper()
+
+	p := make([]core.Fr, n)
+	for i := range p {
+		p[i] = *randomFr(t)
+	}
+
+	// the leading coefficient is not zero
+	p[n-1].SetInt64(int64(n))
+
+	return p
+}
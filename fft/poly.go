@@ -0,0 +1,141 @@
+package fft
+
+import (
+	"errors"
+
+	"github.com/0xPolygon/bnsnark1/core"
+)
+
+var errDivisionByZero = errors.New("division by the zero polynomial")
+
+// Multiply returns the product of the polynomials, computed by multiplying their evaluations
+// over a domain large enough for the product
+func Multiply(a, b []core.Fr) ([]core.Fr, error) {
+	a, b = trim(a), trim(b)
+	if len(a) == 0 || len(b) == 0 {
+		return []core.Fr{}, nil
+	}
+
+	n := len(a) + len(b) - 1
+
+	size := uint64(1)
+	for size < uint64(n) {
+		size <<= 1
+	}
+
+	d, err := NewDomain(size)
+	if err != nil {
+		return nil, err
+	}
+
+	ea, err := d.Evaluations(a)
+	if err != nil {
+		return nil, err
+	}
+
+	eb, err := d.Evaluations(b)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range ea {
+		core.FrMul(&ea[i], &ea[i], &eb[i])
+	}
+
+	if err := d.InverseFFT(ea); err != nil {
+		return nil, err
+	}
+
+	return ea[:n], nil
+}
+
+// Divide returns the quotient and the remainder of a / b by long division
+func Divide(a, b []core.Fr) ([]core.Fr, []core.Fr, error) {
+	b = trim(b)
+	if len(b) == 0 {
+		return nil, nil, errDivisionByZero
+	}
+
+	rem := append([]core.Fr{}, trim(a)...)
+	if len(rem) < len(b) {
+		return []core.Fr{}, rem, nil
+	}
+
+	quo := make([]core.Fr, len(rem)-len(b)+1)
+	db := len(b) - 1
+
+	var lead, t core.Fr
+
+	core.FrInv(&lead, &b[db])
+
+	for i := len(quo) - 1; i >= 0; i-- {
+		core.FrMul(&quo[i], &rem[i+db], &lead)
+
+		for j := 0; j <= db; j++ {
+			core.FrMul(&t, &quo[i], &b[j])
+			core.FrSub(&rem[i+j], &rem[i+j], &t)
+		}
+	}
+
+	return quo, trim(rem[:db]), nil
+}
+
+// VanishingPolynomial returns x^Size - 1, which is zero on the whole domain
+func (d *Domain) VanishingPolynomial() []core.Fr {
+	res := make([]core.Fr, d.Size+1)
+
+	res[0].SetInt64(-1)
+	res[d.Size].SetInt64(1)
+
+	return res
+}
+
+// EvaluateVanishing returns x^Size - 1
+func (d *Domain) EvaluateVanishing(x *core.Fr) *core.Fr {
+	res := *x
+
+	for i := 0; i < d.logSize; i++ {
+		core.FrSqr(&res, &res)
+	}
+
+	core.FrSub(&res, &res, One())
+
+	return &res
+}
+
+// DivideByVanishing returns the quotient and the remainder of a / (x^Size - 1) in linear time
+func (d *Domain) DivideByVanishing(a []core.Fr) ([]core.Fr, []core.Fr) {
+	n := int(d.Size)
+	if len(a) <= n {
+		return []core.Fr{}, trim(append([]core.Fr{}, a...))
+	}
+
+	rem := append([]core.Fr{}, a...)
+	quo := make([]core.Fr, len(a)-n)
+
+	// x^i = x^(i-n) (x^n - 1) + x^(i-n)
+	for i := len(rem) - 1; i >= n; i-- {
+		quo[i-n] = rem[i]
+		core.FrAdd(&rem[i-n], &rem[i-n], &rem[i])
+	}
+
+	return quo, trim(rem[:n])
+}
+
+// trim drops the leading zero coefficients
+func trim(a []core.Fr) []core.Fr {
+	n := len(a)
+	for n > 0 && a[n-1].IsZero() {
+		n--
+	}
+
+	return a[:n]
+}
+
+// One returns a new element of Fr set to 1
+func One() *core.Fr {
+	one := new(core.Fr)
+	one.SetInt64(1)
+
+	return one
+}
@@ -0,0 +1,211 @@
+package groth16
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/0xPolygon/bnsnark1/core"
+)
+
+// gnark writes big-endian coordinates, G2 coordinates with the imaginary part first. The two most
+// significant bits of a point are flags for its encoding
+const (
+	gnarkMask         = 0b11 << 6
+	gnarkUncompressed = 0b00 << 6
+	gnarkInfinity     = 0b01 << 6
+	gnarkSmallest     = 0b10 << 6
+	gnarkLargest      = 0b11 << 6
+
+	gnarkFpSize = 32
+
+	// maxGnarkPublicInputs bounds the length read from an untrusted verifying key
+	maxGnarkPublicInputs = 1 << 20
+)
+
+var errGnarkFormat = errors.New("unsupported gnark encoding")
+
+// ReadGnarkVerifyingKey reads a verifying key written by gnark, compressed with WriteTo or not with WriteRawTo.
+// Verifying keys of circuits with commitments are rejected
+func ReadGnarkVerifyingKey(r io.Reader) (*VerifyingKey, error) {
+	vk := new(VerifyingKey)
+	d := &gnarkDecoder{r: r}
+
+	// beta and delta are also written in G1, for the verifiers that need them
+	var beta1, delta1 core.G1
+
+	d.readG1(&vk.Alpha)
+	d.readG1(&beta1)
+	d.readG2(&vk.Beta)
+	d.readG2(&vk.Gamma)
+	d.readG1(&delta1)
+	d.readG2(&vk.Delta)
+
+	n := d.readLength()
+	if d.err == nil && (n == 0 || n > maxGnarkPublicInputs+1) {
+		return nil, fmt.Errorf("%w: %d public input commitments", errGnarkFormat, n)
+	}
+
+	if d.err == nil {
+		vk.IC = make([]core.G1, n)
+		for i := range vk.IC {
+			d.readG1(&vk.IC[i])
+		}
+	}
+
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	// the public inputs of the commitments followed by the commitment key, older versions of gnark stop before
+	if n := d.readLength(); d.err == nil && n != 0 {
+		return nil, fmt.Errorf("%w: commitments are not supported", errGnarkFormat)
+	}
+
+	if d.err != nil && !errors.Is(d.err, io.EOF) {
+		return nil, d.err
+	}
+
+	return vk, nil
+}
+
+// ReadGnarkProof reads a proof written by gnark, compressed with WriteTo or not with WriteRawTo.
+// Proofs with commitments are rejected
+func ReadGnarkProof(r io.Reader) (*Proof, error) {
+	proof := new(Proof)
+	d := &gnarkDecoder{r: r}
+
+	d.readG1(&proof.A)
+	d.readG2(&proof.B)
+	d.readG1(&proof.C)
+
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	// older versions of gnark stop after C
+	if n := d.readLength(); d.err == nil && n != 0 {
+		return nil, fmt.Errorf("%w: commitments are not supported", errGnarkFormat)
+	}
+
+	if d.err != nil && !errors.Is(d.err, io.EOF) {
+		return nil, d.err
+	}
+
+	return proof, nil
+}
+
+// gnarkDecoder keeps the first error, the reads after it do nothing
+type gnarkDecoder struct {
+	r   io.Reader
+	err error
+}
+
+func (d *gnarkDecoder) read(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+
+	buf := make([]byte, n)
+	if _, d.err = io.ReadFull(d.r, buf); d.err != nil {
+		return nil
+	}
+
+	return buf
+}
+
+func (d *gnarkDecoder) readLength() uint32 {
+	buf := d.read(4)
+	if buf == nil {
+		return 0
+	}
+
+	return binary.BigEndian.Uint32(buf)
+}
+
+// readPoint reads the flags and the coordinates of a point with n field elements in its x coordinate
+func (d *gnarkDecoder) readPoint(n int) (byte, []*big.Int) {
+	first := d.read(n * gnarkFpSize)
+	if first == nil {
+		return 0, nil
+	}
+
+	flags := first[0] & gnarkMask
+	first[0] &^= gnarkMask
+
+	buf := first
+	if flags == gnarkUncompressed {
+		buf = append(buf, d.read(n*gnarkFpSize)...)
+	}
+
+	if d.err != nil {
+		return 0, nil
+	}
+
+	coordinates := make([]*big.Int, len(buf)/gnarkFpSize)
+	for i := range coordinates {
+		coordinates[i] = new(big.Int).SetBytes(buf[i*gnarkFpSize : (i+1)*gnarkFpSize])
+	}
+
+	return flags, coordinates
+}
+
+func (d *gnarkDecoder) readG1(p *core.G1) {
+	flags, v := d.readPoint(1)
+	if d.err != nil {
+		return
+	}
+
+	var res *core.G1
+
+	switch flags {
+	case gnarkUncompressed:
+		res, d.err = newG1(v[0], v[1])
+	case gnarkInfinity:
+		if v[0].Sign() != 0 {
+			d.err = fmt.Errorf("%w: infinity with a non-zero coordinate", errGnarkFormat)
+
+			return
+		}
+
+		res = new(core.G1)
+		res.Clear()
+	default:
+		res, d.err = decompressG1(v[0], flags == gnarkLargest)
+	}
+
+	if d.err == nil {
+		*p = *res
+	}
+}
+
+func (d *gnarkDecoder) readG2(p *core.G2) {
+	flags, v := d.readPoint(2)
+	if d.err != nil {
+		return
+	}
+
+	var res *core.G2
+
+	switch flags {
+	case gnarkUncompressed:
+		res, d.err = newG2(v[1], v[0], v[3], v[2])
+	case gnarkInfinity:
+		if v[0].Sign() != 0 || v[1].Sign() != 0 {
+			d.err = fmt.Errorf("%w: infinity with a non-zero coordinate", errGnarkFormat)
+
+			return
+		}
+
+		res = new(core.G2)
+		res.Clear()
+	default:
+		res, d.err = decompressG2(v[1], v[0], flags == gnarkLargest)
+	}
+
+	if d.err == nil {
+		*p = *res
+	}
+}
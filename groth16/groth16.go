@@ -0,0 +1,177 @@
+// Package groth16 verifies Groth16 proofs over BN254, the curve called bn128 by snarkjs.
+//
+// Verifying keys and proofs are read from the verification_key.json and proof.json files of snarkjs
+// and from the binary encoding of gnark, compressed or not. A proof of the public inputs x is valid if
+//
+//	e(A, B) == e(alpha, beta) * e(IC[0] + sum x_i IC[i+1], gamma) * e(C, delta)
+//
+// Proofs of gnark circuits with commitments are not supported
+package groth16
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/bnsnark1/core"
+)
+
+var (
+	// ErrInvalidProof is returned when the pairing check of the proof fails
+	ErrInvalidProof = errors.New("invalid groth16 proof")
+
+	errPublicInputs   = errors.New("number of public inputs does not match the verifying key")
+	errLengthMismatch = errors.New("number of proofs and of public inputs differ")
+	errInputRange     = errors.New("public input is not below the order of Fr")
+	errRandomScalar   = errors.New("error generating random scalar")
+)
+
+// VerifyingKey is the verifying key of a circuit
+type VerifyingKey struct {
+	Alpha core.G1
+	Beta  core.G2
+	Gamma core.G2
+	Delta core.G2
+	// IC are the commitments to the public inputs, IC[0] to the constant one
+	IC []core.G1
+}
+
+// Proof is a Groth16 proof
+type Proof struct {
+	A core.G1
+	B core.G2
+	C core.G1
+}
+
+// NumPublicInputs returns the number of public inputs of the circuit
+func (vk *VerifyingKey) NumPublicInputs() int {
+	return len(vk.IC) - 1
+}
+
+// Verify checks the proof of the public inputs, it returns ErrInvalidProof if the pairing check fails
+func (vk *VerifyingKey) Verify(proof *Proof, publicInputs []core.Fr) error {
+	ic, err := vk.inputCommitment(publicInputs)
+	if err != nil {
+		return err
+	}
+
+	var negA core.G1
+
+	core.G1Neg(&negA, &proof.A)
+
+	if !pairingIsOne(
+		[]core.G1{negA, vk.Alpha, *ic, proof.C},
+		[]core.G2{proof.B, vk.Beta, vk.Gamma, vk.Delta},
+	) {
+		return ErrInvalidProof
+	}
+
+	return nil
+}
+
+// BatchVerify checks many proofs with a single final exponentiation using a random linear combination:
+//
+//	prod e(r_i A_i, B_i) == e(sum r_i alpha, beta) * e(sum r_i IC_i, gamma) * e(sum r_i C_i, delta)
+//
+// It returns ErrInvalidProof if any proof is invalid, without telling which one
+func (vk *VerifyingKey) BatchVerify(proofs []*Proof, publicInputs [][]core.Fr) error {
+	n := len(proofs)
+	if len(publicInputs) != n {
+		return errLengthMismatch
+	}
+
+	if n == 0 {
+		return nil
+	}
+
+	g1s := make([]core.G1, 0, n+3)
+	g2s := make([]core.G2, 0, n+3)
+	ics := make([]core.G1, n)
+	cs := make([]core.G1, n)
+	r := make([]core.Fr, n)
+
+	var sumR core.Fr
+
+	for i, proof := range proofs {
+		ic, err := vk.inputCommitment(publicInputs[i])
+		if err != nil {
+			return err
+		}
+
+		if !r[i].SetByCSPRNG() {
+			return errRandomScalar
+		}
+
+		var negA core.G1
+
+		core.G1Mul(&negA, &proof.A, &r[i])
+		core.G1Neg(&negA, &negA)
+
+		g1s = append(g1s, negA)
+		g2s = append(g2s, proof.B)
+
+		ics[i], cs[i] = *ic, proof.C
+
+		core.FrAdd(&sumR, &sumR, &r[i])
+	}
+
+	var alpha, ic, c core.G1
+
+	core.G1Mul(&alpha, &vk.Alpha, &sumR)
+	core.G1MulVec(&ic, ics, r)
+	core.G1MulVec(&c, cs, r)
+
+	g1s = append(g1s, alpha, ic, c)
+	g2s = append(g2s, vk.Beta, vk.Gamma, vk.Delta)
+
+	if !pairingIsOne(g1s, g2s) {
+		return ErrInvalidProof
+	}
+
+	return nil
+}
+
+// inputCommitment returns IC[0] + sum publicInputs[i] IC[i+1]
+func (vk *VerifyingKey) inputCommitment(publicInputs []core.Fr) (*core.G1, error) {
+	if len(vk.IC) == 0 || len(publicInputs) != vk.NumPublicInputs() {
+		return nil, fmt.Errorf("%w: got %d, expected %d", errPublicInputs, len(publicInputs), vk.NumPublicInputs())
+	}
+
+	ic := new(core.G1)
+	core.G1MulVec(ic, vk.IC[1:], publicInputs)
+	core.G1Add(ic, ic, &vk.IC[0])
+
+	return ic, nil
+}
+
+// ParsePublicInputs converts the decimal public inputs, e.g. of the public.json file of snarkjs, to Fr
+func ParsePublicInputs(values []string) ([]core.Fr, error) {
+	order, _ := new(big.Int).SetString(core.GetCurveOrder(), 10)
+	res := make([]core.Fr, len(values))
+
+	for i, value := range values {
+		v, ok := new(big.Int).SetString(value, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid public input %q", value)
+		}
+
+		if v.Sign() < 0 || v.Cmp(order) >= 0 {
+			return nil, fmt.Errorf("%w: %s", errInputRange, value)
+		}
+
+		if err := res[i].SetString(v.String(), 10); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func pairingIsOne(g1s []core.G1, g2s []core.G2) bool {
+	e := new(core.GT)
+
+	core.MillerLoopVec(e, g1s, g2s)
+	core.FinalExp(e, e)
+
+	return e.IsOne()
+}
@@ -0,0 +1,216 @@
+package groth16
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/bnsnark1/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The vectors in testdata were generated by gnark v0.9.1 for the circuit x^3 + x + 5 == y, x * z == w
+// with the public inputs y, z, w, and converted to the files of snarkjs
+const numProofs = 3
+
+func TestReadVerifyingKey(t *testing.T) {
+	t.Parallel()
+
+	vk := testVerifyingKey(t)
+	require.Equal(t, 3, vk.NumPublicInputs())
+
+	for _, name := range []string{"verification_key.bin", "verification_key_raw.bin"} {
+		gnarkVK, err := ReadGnarkVerifyingKey(bytes.NewReader(readTestdata(t, name)))
+		require.NoError(t, err, name)
+
+		assert.True(t, vk.Alpha.IsEqual(&gnarkVK.Alpha), name)
+		assert.True(t, vk.Beta.IsEqual(&gnarkVK.Beta), name)
+		assert.True(t, vk.Gamma.IsEqual(&gnarkVK.Gamma), name)
+		assert.True(t, vk.Delta.IsEqual(&gnarkVK.Delta), name)
+		require.Len(t, gnarkVK.IC, len(vk.IC), name)
+
+		for i := range vk.IC {
+			assert.True(t, vk.IC[i].IsEqual(&gnarkVK.IC[i]), name)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	vk := testVerifyingKey(t)
+
+	for i := 0; i < numProofs; i++ {
+		inputs := publicInputs(t, i)
+
+		proofs := []*Proof{testProof(t, i)}
+
+		for _, name := range []string{"proof_%d.bin", "proof_%d_raw.bin"} {
+			proof, err := ReadGnarkProof(bytes.NewReader(readTestdata(t, fmt.Sprintf(name, i))))
+			require.NoError(t, err)
+
+			proofs = append(proofs, proof)
+		}
+
+		for _, proof := range proofs {
+			require.NoError(t, vk.Verify(proof, inputs))
+
+			// the proof of another statement
+			wrong := append([]core.Fr{}, inputs...)
+			core.FrAdd(&wrong[0], &wrong[0], &wrong[1])
+			assert.ErrorIs(t, vk.Verify(proof, wrong), ErrInvalidProof)
+
+			assert.ErrorIs(t, vk.Verify(proof, inputs[1:]), errPublicInputs)
+		}
+
+		// a proof with another A
+		proof := *proofs[0]
+		core.G1Add(&proof.A, &proof.A, &proof.A)
+		assert.ErrorIs(t, vk.Verify(&proof, inputs), ErrInvalidProof)
+	}
+}
+
+func TestBatchVerify(t *testing.T) {
+	t.Parallel()
+
+	vk := testVerifyingKey(t)
+
+	proofs := make([]*Proof, numProofs)
+	inputs := make([][]core.Fr, numProofs)
+
+	for i := range proofs {
+		proofs[i] = testProof(t, i)
+		inputs[i] = publicInputs(t, i)
+	}
+
+	require.NoError(t, vk.BatchVerify(proofs, inputs))
+	require.NoError(t, vk.BatchVerify(nil, nil))
+
+	// the proofs are valid, but not of the swapped statements
+	inputs[0], inputs[1] = inputs[1], inputs[0]
+	assert.ErrorIs(t, vk.BatchVerify(proofs, inputs), ErrInvalidProof)
+
+	assert.ErrorIs(t, vk.BatchVerify(proofs, inputs[1:]), errLengthMismatch)
+}
+
+func TestReadSnarkjs_Invalid(t *testing.T) {
+	t.Parallel()
+
+	var vk map[string]interface{}
+
+	require.NoError(t, json.Unmarshal(readTestdata(t, "verification_key.json"), &vk))
+
+	vk["nPublic"] = 2
+	data, err := json.Marshal(vk)
+	require.NoError(t, err)
+
+	_, err = ReadSnarkjsVerifyingKey(data)
+	assert.ErrorIs(t, err, errSnarkjsFormat)
+
+	vk["nPublic"], vk["curve"] = 3, "bls12381"
+	data, err = json.Marshal(vk)
+	require.NoError(t, err)
+
+	_, err = ReadSnarkjsVerifyingKey(data)
+	assert.ErrorIs(t, err, errSnarkjsFormat)
+
+	var proof map[string]interface{}
+
+	require.NoError(t, json.Unmarshal(readTestdata(t, "proof_0.json"), &proof))
+
+	// a point off the curve
+	proof["pi_a"].([]interface{})[1] = "1"
+	data, err = json.Marshal(proof)
+	require.NoError(t, err)
+
+	_, err = ReadSnarkjsProof(data)
+	assert.ErrorIs(t, err, core.ErrPointNotOnCurve)
+
+	// the field order
+	proof["pi_a"].([]interface{})[1] = core.GetFieldOrder()
+	data, err = json.Marshal(proof)
+	require.NoError(t, err)
+
+	_, err = ReadSnarkjsProof(data)
+	assert.ErrorIs(t, err, errCoordinateRange)
+
+	_, err = ParsePublicInputs([]string{core.GetCurveOrder()})
+	assert.ErrorIs(t, err, errInputRange)
+}
+
+func TestReadGnark_Invalid(t *testing.T) {
+	t.Parallel()
+
+	// the length of the commitments follows A, B and C
+	for name, offset := range map[string]int{"proof_0.bin": 128, "proof_0_raw.bin": 256} {
+		data := readTestdata(t, name)
+
+		_, err := ReadGnarkProof(bytes.NewReader(data[:offset-1]))
+		assert.Error(t, err, name)
+
+		// a proof with commitments
+		withCommitments := append([]byte{}, data...)
+		withCommitments[offset+3] = 1
+
+		_, err = ReadGnarkProof(bytes.NewReader(withCommitments))
+		assert.ErrorIs(t, err, errGnarkFormat, name)
+
+		// the encoding of older versions of gnark
+		proof, err := ReadGnarkProof(bytes.NewReader(data[:offset]))
+		require.NoError(t, err, name)
+		assert.NoError(t, testVerifyingKey(t).Verify(proof, publicInputs(t, 0)))
+	}
+
+	// x of A is the field order
+	data := readTestdata(t, "proof_0.bin")
+	fieldOrder.FillBytes(data[:gnarkFpSize])
+	data[0] |= gnarkSmallest
+
+	_, err := ReadGnarkProof(bytes.NewReader(data))
+	assert.ErrorIs(t, err, errCoordinateRange)
+
+	// the verifying key is cut in the last IC
+	data = readTestdata(t, "verification_key.bin")
+	_, err = ReadGnarkVerifyingKey(bytes.NewReader(data[:9*gnarkFpSize+4+4*gnarkFpSize-1]))
+	assert.Error(t, err)
+}
+
+func testVerifyingKey(t *testing.T) *VerifyingKey {
+	t.Helper()
+
+	vk, err := ReadSnarkjsVerifyingKey(readTestdata(t, "verification_key.json"))
+	require.NoError(t, err)
+
+	return vk
+}
+
+func testProof(t *testing.T, i int) *Proof {
+	t.Helper()
+
+	proof, err := ReadSnarkjsProof(readTestdata(t, fmt.Sprintf("proof_%d.json", i)))
+	require.NoError(t, err)
+
+	return proof
+}
+
+func publicInputs(t *testing.T, i int) []core.Fr {
+	t.Helper()
+
+	inputs, err := ReadSnarkjsPublicInputs(readTestdata(t, fmt.Sprintf("public_%d.json", i)))
+	require.NoError(t, err)
+
+	return inputs
+}
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+
+	return data
+}
@@ -0,0 +1,178 @@
+package groth16
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/bnsnark1/core"
+)
+
+var errCoordinateRange = errors.New("coordinate is not below the field order")
+
+var fieldOrder, _ = new(big.Int).SetString(core.GetFieldOrder(), 10)
+
+// newFp converts the coordinate, which must be below the field order
+func newFp(v *big.Int) (core.Fp, error) {
+	var fp core.Fp
+
+	if v.Sign() < 0 || v.Cmp(fieldOrder) >= 0 {
+		return fp, fmt.Errorf("%w: %s", errCoordinateRange, v)
+	}
+
+	return fp, fp.SetString(v.String(), 10)
+}
+
+// newG1 returns the point of the affine coordinates, (0, 0) is the identity.
+// The point must be on the curve, which for G1 means in the group
+func newG1(x, y *big.Int) (*core.G1, error) {
+	p := new(core.G1)
+
+	if x.Sign() == 0 && y.Sign() == 0 {
+		p.Clear()
+
+		return p, nil
+	}
+
+	var err error
+
+	if p.X, err = newFp(x); err != nil {
+		return nil, err
+	}
+
+	if p.Y, err = newFp(y); err != nil {
+		return nil, err
+	}
+
+	p.Z.SetInt64(1)
+
+	return p, validateG1(p)
+}
+
+// newG2 returns the point of the affine coordinates x0 + x1*u, y0 + y1*u, (0, 0) is the identity.
+// The point must be on the twist and in the prime-order subgroup
+func newG2(x0, x1, y0, y1 *big.Int) (*core.G2, error) {
+	p := new(core.G2)
+
+	if x0.Sign() == 0 && x1.Sign() == 0 && y0.Sign() == 0 && y1.Sign() == 0 {
+		p.Clear()
+
+		return p, nil
+	}
+
+	for i, v := range []*big.Int{x0, x1, y0, y1} {
+		fp, err := newFp(v)
+		if err != nil {
+			return nil, err
+		}
+
+		switch i {
+		case 0, 1:
+			p.X.D[i] = fp
+		default:
+			p.Y.D[i-2] = fp
+		}
+	}
+
+	p.Z.D[0].SetInt64(1)
+
+	return p, validateG2(p)
+}
+
+// decompressG1 returns the point with the x coordinate and the lexicographically largest or smallest y
+func decompressG1(x *big.Int, largest bool) (*core.G1, error) {
+	p := new(core.G1)
+
+	var err error
+
+	if p.X, err = newFp(x); err != nil {
+		return nil, err
+	}
+
+	// y^2 = x^3 + 3
+	var y2, three core.Fp
+
+	three.SetInt64(3)
+	core.FpSqr(&y2, &p.X)
+	core.FpMul(&y2, &y2, &p.X)
+	core.FpAdd(&y2, &y2, &three)
+
+	if !core.FpSquareRoot(&p.Y, &y2) {
+		return nil, core.ErrPointNotOnCurve
+	}
+
+	if fpIsLargest(&p.Y) != largest {
+		core.FpNeg(&p.Y, &p.Y)
+	}
+
+	p.Z.SetInt64(1)
+
+	return p, validateG1(p)
+}
+
+// decompressG2 returns the point with the x coordinate and the lexicographically largest or smallest y
+func decompressG2(x0, x1 *big.Int, largest bool) (*core.G2, error) {
+	p := new(core.G2)
+
+	for i, v := range []*big.Int{x0, x1} {
+		fp, err := newFp(v)
+		if err != nil {
+			return nil, err
+		}
+
+		p.X.D[i] = fp
+	}
+
+	// y^2 = x^3 + 3 / (9 + u)
+	var y2, b, xi core.Fp2
+
+	b.D[0].SetInt64(3)
+	xi.D[0].SetInt64(9)
+	xi.D[1].SetInt64(1)
+	core.Fp2Div(&b, &b, &xi)
+
+	core.Fp2Sqr(&y2, &p.X)
+	core.Fp2Mul(&y2, &y2, &p.X)
+	core.Fp2Add(&y2, &y2, &b)
+
+	if !core.Fp2SquareRoot(&p.Y, &y2) {
+		return nil, core.ErrPointNotOnCurve
+	}
+
+	// the imaginary part decides unless it is zero
+	largest2 := fpIsLargest(&p.Y.D[1])
+	if p.Y.D[1].IsZero() {
+		largest2 = fpIsLargest(&p.Y.D[0])
+	}
+
+	if largest2 != largest {
+		core.Fp2Neg(&p.Y, &p.Y)
+	}
+
+	p.Z.D[0].SetInt64(1)
+
+	return p, validateG2(p)
+}
+
+// fpIsLargest tells whether x > (p - 1) / 2
+func fpIsLargest(x *core.Fp) bool {
+	v, _ := new(big.Int).SetString(x.GetString(10), 10)
+
+	return v.Lsh(v, 1).Cmp(fieldOrder) > 0
+}
+
+func validateG1(p *core.G1) error {
+	if err := core.ValidateG1(p); err != nil && !errors.Is(err, core.ErrIdentityPoint) {
+		return err
+	}
+
+	return nil
+}
+
+func validateG2(p *core.G2) error {
+	if err := core.ValidateG2(p); err != nil && !errors.Is(err, core.ErrIdentityPoint) {
+		return err
+	}
+
+	return nil
+}
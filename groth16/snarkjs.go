@@ -0,0 +1,199 @@
+package groth16
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygon/bnsnark1/core"
+)
+
+// snarkjs writes the points as decimal projective coordinates, which are affine with z = 1
+// or the identity with z = 0. G2 coordinates are [c0, c1] for c0 + c1*u
+var errSnarkjsFormat = errors.New("unsupported snarkjs file")
+
+type snarkjsVerifyingKey struct {
+	Protocol string     `json:"protocol"`
+	Curve    string     `json:"curve"`
+	NPublic  int        `json:"nPublic"`
+	Alpha    []string   `json:"vk_alpha_1"`
+	Beta     [][]string `json:"vk_beta_2"`
+	Gamma    [][]string `json:"vk_gamma_2"`
+	Delta    [][]string `json:"vk_delta_2"`
+	IC       [][]string `json:"IC"`
+}
+
+type snarkjsProof struct {
+	Protocol string     `json:"protocol"`
+	Curve    string     `json:"curve"`
+	A        []string   `json:"pi_a"`
+	B        [][]string `json:"pi_b"`
+	C        []string   `json:"pi_c"`
+}
+
+// ReadSnarkjsVerifyingKey reads the verification_key.json file of snarkjs
+func ReadSnarkjsVerifyingKey(data []byte) (*VerifyingKey, error) {
+	var raw snarkjsVerifyingKey
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	if err := checkSnarkjsHeader(raw.Protocol, raw.Curve); err != nil {
+		return nil, err
+	}
+
+	if raw.NPublic+1 != len(raw.IC) {
+		return nil, fmt.Errorf("%w: nPublic is %d but there are %d IC", errSnarkjsFormat, raw.NPublic, len(raw.IC))
+	}
+
+	vk := &VerifyingKey{IC: make([]core.G1, len(raw.IC))}
+
+	alpha, err := snarkjsG1(raw.Alpha)
+	if err != nil {
+		return nil, fmt.Errorf("vk_alpha_1: %w", err)
+	}
+
+	vk.Alpha = *alpha
+
+	for _, g2 := range []struct {
+		name  string
+		value [][]string
+		out   *core.G2
+	}{
+		{"vk_beta_2", raw.Beta, &vk.Beta},
+		{"vk_gamma_2", raw.Gamma, &vk.Gamma},
+		{"vk_delta_2", raw.Delta, &vk.Delta},
+	} {
+		p, err := snarkjsG2(g2.value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", g2.name, err)
+		}
+
+		*g2.out = *p
+	}
+
+	for i, value := range raw.IC {
+		p, err := snarkjsG1(value)
+		if err != nil {
+			return nil, fmt.Errorf("IC %d: %w", i, err)
+		}
+
+		vk.IC[i] = *p
+	}
+
+	return vk, nil
+}
+
+// ReadSnarkjsProof reads the proof.json file of snarkjs
+func ReadSnarkjsProof(data []byte) (*Proof, error) {
+	var raw snarkjsProof
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	if err := checkSnarkjsHeader(raw.Protocol, raw.Curve); err != nil {
+		return nil, err
+	}
+
+	a, err := snarkjsG1(raw.A)
+	if err != nil {
+		return nil, fmt.Errorf("pi_a: %w", err)
+	}
+
+	b, err := snarkjsG2(raw.B)
+	if err != nil {
+		return nil, fmt.Errorf("pi_b: %w", err)
+	}
+
+	c, err := snarkjsG1(raw.C)
+	if err != nil {
+		return nil, fmt.Errorf("pi_c: %w", err)
+	}
+
+	return &Proof{A: *a, B: *b, C: *c}, nil
+}
+
+// ReadSnarkjsPublicInputs reads the public.json file of snarkjs
+func ReadSnarkjsPublicInputs(data []byte) ([]core.Fr, error) {
+	var values []string
+
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+
+	return ParsePublicInputs(values)
+}
+
+func checkSnarkjsHeader(protocol, curve string) error {
+	if protocol != "groth16" || curve != "bn128" {
+		return fmt.Errorf("%w: protocol %q on curve %q", errSnarkjsFormat, protocol, curve)
+	}
+
+	return nil
+}
+
+func snarkjsG1(coordinates []string) (*core.G1, error) {
+	v, err := parseDecimals(coordinates, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case v[2].Sign() == 0:
+		return newG1(new(big.Int), new(big.Int))
+	case v[2].Cmp(big.NewInt(1)) != 0:
+		return nil, fmt.Errorf("%w: point is not affine", errSnarkjsFormat)
+	}
+
+	return newG1(v[0], v[1])
+}
+
+func snarkjsG2(coordinates [][]string) (*core.G2, error) {
+	if len(coordinates) != 3 {
+		return nil, fmt.Errorf("%w: expected 3 coordinates, got %d", errSnarkjsFormat, len(coordinates))
+	}
+
+	var v []*big.Int
+
+	for _, c := range coordinates {
+		parsed, err := parseDecimals(c, 2)
+		if err != nil {
+			return nil, err
+		}
+
+		v = append(v, parsed...)
+	}
+
+	switch {
+	case v[4].Sign() == 0 && v[5].Sign() == 0:
+		zero := new(big.Int)
+
+		return newG2(zero, zero, zero, zero)
+	case v[4].Cmp(big.NewInt(1)) != 0 || v[5].Sign() != 0:
+		return nil, fmt.Errorf("%w: point is not affine", errSnarkjsFormat)
+	}
+
+	return newG2(v[0], v[1], v[2], v[3])
+}
+
+func parseDecimals(values []string, n int) ([]*big.Int, error) {
+	if len(values) != n {
+		return nil, fmt.Errorf("%w: expected %d coordinates, got %d", errSnarkjsFormat, n, len(values))
+	}
+
+	res := make([]*big.Int, n)
+
+	for i, value := range values {
+		v, ok := new(big.Int).SetString(value, 10)
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid number %q", errSnarkjsFormat, value)
+		}
+
+		res[i] = v
+	}
+
+	return res, nil
+}
@@ -0,0 +1,116 @@
+package hd
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// A BIP-39 mnemonic encodes 11 bits per word: the entropy followed by the first entropy bits / 32 bits
+// of its SHA-256 as checksum. The seed is PBKDF2-HMAC-SHA512 of the mnemonic salted with "mnemonic"
+// and the passphrase
+
+const (
+	mnemonicWordBits       = 11
+	mnemonicMinEntropy     = 128
+	mnemonicMaxEntropy     = 256
+	mnemonicSeedIterations = 2048
+	mnemonicSeedSize       = 64
+)
+
+var (
+	errMnemonicEntropy  = errors.New("entropy must be between 128 and 256 bits, a multiple of 32")
+	errMnemonicLength   = errors.New("mnemonic must have 12, 15, 18, 21 or 24 words")
+	errMnemonicWord     = errors.New("mnemonic word is not in the wordlist")
+	errMnemonicChecksum = errors.New("mnemonic checksum mismatch")
+)
+
+var (
+	bip39English = strings.Fields(bip39EnglishWords)
+	bip39Indices = func() map[string]int {
+		indices := make(map[string]int, len(bip39English))
+		for i, word := range bip39English {
+			indices[word] = i
+		}
+
+		return indices
+	}()
+)
+
+// entropyToMnemonic encodes the entropy as words
+func entropyToMnemonic(entropy []byte) (string, error) {
+	bits := 8 * len(entropy)
+	if bits < mnemonicMinEntropy || bits > mnemonicMaxEntropy || bits%32 != 0 {
+		return "", errMnemonicEntropy
+	}
+
+	// the checksum has at most 8 bits
+	checksum := sha256.Sum256(entropy)
+	data := append(append([]byte{}, entropy...), checksum[0])
+
+	words := make([]string, (bits+bits/32)/mnemonicWordBits)
+	for i := range words {
+		words[i] = bip39English[readBits(data, i*mnemonicWordBits, mnemonicWordBits)]
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+// mnemonicToEntropy decodes the words of the mnemonic and checks its checksum.
+// The errors do not quote the words, which are secret
+func mnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+
+	bits := len(words) * mnemonicWordBits
+	if bits < mnemonicMinEntropy+mnemonicMinEntropy/32 || bits > mnemonicMaxEntropy+mnemonicMaxEntropy/32 ||
+		len(words)%3 != 0 {
+		return nil, errMnemonicLength
+	}
+
+	data := make([]byte, (bits+7)/8)
+
+	for i, word := range words {
+		index, ok := bip39Indices[word]
+		if !ok {
+			return nil, fmt.Errorf("%w: word %d", errMnemonicWord, i+1)
+		}
+
+		for j := 0; j < mnemonicWordBits; j++ {
+			if index>>(mnemonicWordBits-1-j)&1 == 1 {
+				pos := i*mnemonicWordBits + j
+				data[pos/8] |= 1 << (7 - pos%8)
+			}
+		}
+	}
+
+	checksumBits := bits / 33
+	entropy := data[:(bits-checksumBits)/8]
+	checksum := sha256.Sum256(entropy)
+
+	if readBits(data, 8*len(entropy), checksumBits) != readBits(checksum[:], 0, checksumBits) {
+		return nil, errMnemonicChecksum
+	}
+
+	return entropy, nil
+}
+
+// mnemonicSeed derives the seed of the normalized mnemonic and passphrase
+func mnemonicSeed(mnemonic, passphrase string) []byte {
+	return pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"+passphrase), mnemonicSeedIterations, mnemonicSeedSize,
+		sha512.New)
+}
+
+// readBits returns the n bits of data starting at the bit offset, most significant bit first
+func readBits(data []byte, offset, n int) int {
+	v := 0
+
+	for i := offset; i < offset+n; i++ {
+		v = v<<1 | int(data[i/8]>>(7-i%8)&1)
+	}
+
+	return v
+}
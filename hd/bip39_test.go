@@ -0,0 +1,103 @@
+package hd
+
+import (
+	"encoding/hex"
+	"hash/crc32"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBIP39_Wordlist(t *testing.T) {
+	t.Parallel()
+
+	// the CRC-32 of english.txt
+	assert.Equal(t, uint32(0xc1dbd296), crc32.ChecksumIEEE([]byte(bip39EnglishWords)))
+	assert.Len(t, bip39English, 1<<mnemonicWordBits)
+	assert.Len(t, bip39Indices, 1<<mnemonicWordBits)
+}
+
+func TestBIP39_Vectors(t *testing.T) {
+	t.Parallel()
+
+	// the english vectors of BIP-39 with the passphrase TREZOR
+	cases := []struct {
+		entropy  string
+		mnemonic string
+		seed     string
+	}{
+		{
+			entropy:  "00000000000000000000000000000000",
+			mnemonic: strings.Repeat("abandon ", 11) + "about",
+			seed:     hdTestSeed,
+		},
+		{
+			entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+			seed: "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6f" +
+				"a457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			entropy:  "80808080808080808080808080808080",
+			mnemonic: "letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+			seed: "d71de856f81a8acc65e6fc851a38d4d7ec216fd0796d0a6827a3ad6ed5511a30" +
+				"fa280f12eb2e47ed2ac03b5c462a0358d18d69fe4f985ec81778c1b370b652a8",
+		},
+		{
+			entropy:  "ffffffffffffffffffffffffffffffff",
+			mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+			seed: "ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13" +
+				"332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+		},
+		{
+			entropy: "68a79eaca2324873eacc50cb9c6eca8cc68ea5d936f98787c60c7ebc74e6ce7c",
+			mnemonic: "hamster diagram private dutch cause delay private meat slide toddler razor book " +
+				"happy fancy gospel tennis maple dilemma loan word shrug inflict delay length",
+			seed: "64c87cde7e12ecf6704ab95bb1408bef047c22db4cc7491c4271d170a1b213d2" +
+				"0b385bc1588d9c7b38f1b39d415665b8a9030c9ec653d75e65f847d8fc1fc440",
+		},
+	}
+
+	for _, c := range cases {
+		entropy, err := hex.DecodeString(c.entropy)
+		require.NoError(t, err)
+
+		mnemonic, err := entropyToMnemonic(entropy)
+		require.NoError(t, err)
+		assert.Equal(t, c.mnemonic, mnemonic)
+
+		decoded, err := mnemonicToEntropy(mnemonic)
+		require.NoError(t, err)
+		assert.Equal(t, entropy, decoded)
+
+		seed, err := MnemonicToSeed(mnemonic, "TREZOR")
+		require.NoError(t, err)
+		assert.Equal(t, c.seed, hex.EncodeToString(seed))
+	}
+}
+
+func TestBIP39_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, size := range []int{0, 12, 17, 36} {
+		_, err := entropyToMnemonic(make([]byte, size))
+		assert.ErrorIs(t, err, errMnemonicEntropy, size)
+	}
+
+	for _, mnemonic := range []string{"", strings.Repeat("abandon ", 10) + "about", strings.Repeat("abandon ", 27)} {
+		_, err := mnemonicToEntropy(mnemonic)
+		assert.ErrorIs(t, err, errMnemonicLength, mnemonic)
+	}
+
+	_, err := mnemonicToEntropy(strings.Repeat("abandon ", 11) + "abut")
+	assert.ErrorIs(t, err, errMnemonicWord)
+	assert.NotContains(t, err.Error(), "abut")
+
+	_, err = mnemonicToEntropy(strings.Repeat("abandon ", 12))
+	assert.ErrorIs(t, err, errMnemonicChecksum)
+
+	_, err = mnemonicToEntropy(strings.Repeat("zoo ", 12))
+	assert.ErrorIs(t, err, errMnemonicChecksum)
+}
@@ -0,0 +1,199 @@
+// Package hd derives BLS private keys from seeds and BIP-39 mnemonics.
+//
+// The hierarchical deterministic derivation follows EIP-2333 with the order of Fr in place of the order
+// of BLS12-381, so the keys differ from the ones derived for BLS12-381 from the same seed.
+// HKDF_mod_r outputs 48 bytes, which is ceil(3 * ceil(log2(r)) / 16) for both curves
+package hd
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/0xPolygon/bnsnark1/core"
+	"golang.org/x/crypto/hkdf"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	hdKeygenSalt     = "BLS-SIG-KEYGEN-SALT-"
+	hdOKMLength      = 48
+	hdMinSeedSize    = 32
+	lamportChunkNum  = 255
+	lamportChunkSize = 32
+	mnemonicEntropy  = 256
+)
+
+var (
+	errHDSeedSize    = errors.New("seed must be at least 32 bytes")
+	errHDInvalidPath = errors.New("invalid derivation path")
+)
+
+// DeriveMasterKey derives the master private key from the seed, which must be at least 32 bytes
+func DeriveMasterKey(seed []byte) (*core.PrivateKey, error) {
+	if len(seed) < hdMinSeedSize {
+		return nil, errHDSeedSize
+	}
+
+	return privateKeyFromBig(hkdfModR(seed, curveOrder()))
+}
+
+// DeriveChildKey derives the child private key at the given index
+func DeriveChildKey(parent *core.PrivateKey, index uint32) (*core.PrivateKey, error) {
+	sk, ok := new(big.Int).SetString(parent.Scalar().GetString(10), 10)
+	if !ok {
+		return nil, errors.New("invalid parent key")
+	}
+
+	return privateKeyFromBig(deriveChildSK(sk, index, curveOrder()))
+}
+
+// DeriveKeyFromPath derives the private key of the path, e.g. m/12381/60/0/0, from the seed
+func DeriveKeyFromPath(seed []byte, path string) (*core.PrivateKey, error) {
+	indices, err := parseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := DeriveMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range indices {
+		if key, err = DeriveChildKey(key, index); err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// NewMnemonic generates a random BIP-39 mnemonic of 24 english words
+func NewMnemonic() (string, error) {
+	entropy := make([]byte, mnemonicEntropy/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+
+	return entropyToMnemonic(entropy)
+}
+
+// MnemonicToSeed validates the BIP-39 mnemonic and converts it to the 64 bytes seed with the passphrase
+func MnemonicToSeed(mnemonic, passphrase string) ([]byte, error) {
+	mnemonic = norm.NFKD.String(strings.Join(strings.Fields(mnemonic), " "))
+
+	if _, err := mnemonicToEntropy(mnemonic); err != nil {
+		return nil, err
+	}
+
+	return mnemonicSeed(mnemonic, norm.NFKD.String(passphrase)), nil
+}
+
+// DeriveKeyFromMnemonic derives the private key of the path from the BIP-39 mnemonic and passphrase
+func DeriveKeyFromMnemonic(mnemonic, passphrase, path string) (*core.PrivateKey, error) {
+	seed, err := MnemonicToSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return DeriveKeyFromPath(seed, path)
+}
+
+// hkdfModR is HKDF_mod_r of EIP-2333 with an empty key_info
+func hkdfModR(ikm []byte, order *big.Int) *big.Int {
+	salt := []byte(hdKeygenSalt)
+	sk := new(big.Int)
+	okm := make([]byte, hdOKMLength)
+
+	for sk.Sign() == 0 {
+		h := sha256.Sum256(salt)
+		salt = h[:]
+
+		prk := hkdf.Extract(sha256.New, append(append([]byte{}, ikm...), 0), salt)
+
+		// key_info || I2OSP(L, 2)
+		if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, []byte{0, hdOKMLength}), okm); err != nil {
+			panic(err) // the output is far below the limit of HKDF
+		}
+
+		sk.SetBytes(okm)
+		sk.Mod(sk, order)
+	}
+
+	return sk
+}
+
+func deriveChildSK(parent *big.Int, index uint32, order *big.Int) *big.Int {
+	return hkdfModR(parentSKToLamportPK(parent, index), order)
+}
+
+// parentSKToLamportPK compresses the lamport public key of the parent key at the index
+func parentSKToLamportPK(parent *big.Int, index uint32) []byte {
+	salt := []byte{byte(index >> 24), byte(index >> 16), byte(index >> 8), byte(index)}
+
+	ikm := parent.FillBytes(make([]byte, 32))
+	notIKM := make([]byte, len(ikm))
+
+	for i, b := range ikm {
+		notIKM[i] = ^b
+	}
+
+	lamportPK := sha256.New()
+
+	for _, k := range [][]byte{ikm, notIKM} {
+		okm := make([]byte, lamportChunkNum*lamportChunkSize)
+
+		if _, err := io.ReadFull(hkdf.New(sha256.New, k, salt, nil), okm); err != nil {
+			panic(err) // 8160 bytes is the limit of HKDF with SHA-256
+		}
+
+		for i := 0; i < lamportChunkNum; i++ {
+			chunk := sha256.Sum256(okm[i*lamportChunkSize : (i+1)*lamportChunkSize])
+			_, _ = lamportPK.Write(chunk[:])
+		}
+	}
+
+	return lamportPK.Sum(nil)
+}
+
+// parseDerivationPath parses m/i/j/... into its indices
+func parseDerivationPath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("%w: %s", errHDInvalidPath, path)
+	}
+
+	indices := make([]uint32, len(parts)-1)
+
+	for i, part := range parts[1:] {
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errHDInvalidPath, path)
+		}
+
+		indices[i] = uint32(index)
+	}
+
+	return indices, nil
+}
+
+func curveOrder() *big.Int {
+	order, _ := new(big.Int).SetString(core.GetCurveOrder(), 10)
+
+	return order
+}
+
+func privateKeyFromBig(sk *big.Int) (*core.PrivateKey, error) {
+	fr := new(core.Fr)
+	if err := fr.SetString(sk.String(), 10); err != nil {
+		return nil, err
+	}
+
+	return core.NewPrivateKey(fr), nil
+}
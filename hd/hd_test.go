@@ -0,0 +1,130 @@
+package hd
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// the seed of the BIP-39 test vector "abandon ... about" with the passphrase TREZOR, used by EIP-2333 as well
+const hdTestSeed = "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"
+
+func TestHD_EIP2333Vector(t *testing.T) {
+	t.Parallel()
+
+	// with the order of BLS12-381 the derivation reproduces the vector of EIP-2333
+	order, _ := new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+	seed, err := hex.DecodeString(hdTestSeed)
+	require.NoError(t, err)
+
+	master := hkdfModR(seed, order)
+	assert.Equal(t, "6083874454709270928345386274498605044986640685124978867557563392430687146096", master.String())
+	assert.Equal(t,
+		"20397789859736650942317412262472558107875392172444076792671091975210932703118",
+		deriveChildSK(master, 0, order).String())
+}
+
+func TestHD_Vectors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		seed   string
+		index  uint32
+		master string
+		child  string
+	}{
+		{
+			seed:   hdTestSeed,
+			index:  0,
+			master: "16876385784863514523309488032647671531381760176997820269052892961094459323096",
+			child:  "6261163673700163178650738809658100478163222593983987165305930523660281595207",
+		},
+		{
+			seed:   "3141592653589793238462643383279502884197169399375105820974944592",
+			index:  3141592653,
+			master: "229353659466065015837541496932444945060729173326560345507736288250925376270",
+			child:  "2251622119959225382267678512174892219044747370423865091043915542740175118827",
+		},
+		{
+			seed:   "0099ff991111002299dd7744ee3355bbdd8844115566cc55663355668888cc00",
+			index:  4294967295,
+			master: "18216402388012388254786533226315227966838550967932470819583961139627092135409",
+			child:  "5382347775372111712216110084420056541202794534869398147348620148382560698836",
+		},
+		{
+			seed:   "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3",
+			index:  42,
+			master: "2183211438400025037344214143073701306349415826671090356731890793683104354304",
+			child:  "15693575931479047451749891151725307486820008487903000455863245393333660785642",
+		},
+	}
+
+	for _, c := range cases {
+		seed, err := hex.DecodeString(c.seed)
+		require.NoError(t, err)
+
+		master, err := DeriveMasterKey(seed)
+		require.NoError(t, err)
+		assert.Equal(t, c.master, master.Scalar().GetString(10))
+
+		child, err := DeriveChildKey(master, c.index)
+		require.NoError(t, err)
+		assert.Equal(t, c.child, child.Scalar().GetString(10))
+	}
+}
+
+func TestHD_Mnemonic(t *testing.T) {
+	t.Parallel()
+
+	mnemonic := strings.Repeat("abandon ", 11) + "about"
+
+	seed, err := MnemonicToSeed(mnemonic, "TREZOR")
+	require.NoError(t, err)
+	assert.Equal(t, hdTestSeed, hex.EncodeToString(seed))
+
+	_, err = MnemonicToSeed(strings.Repeat("abandon ", 12), "TREZOR")
+	assert.Error(t, err)
+
+	key, err := DeriveKeyFromMnemonic(mnemonic, "TREZOR", "m/0")
+	require.NoError(t, err)
+	assert.Equal(t, "6261163673700163178650738809658100478163222593983987165305930523660281595207", key.Scalar().GetString(10))
+
+	generated, err := NewMnemonic()
+	require.NoError(t, err)
+	assert.Len(t, strings.Fields(generated), 24)
+
+	first, err := DeriveKeyFromMnemonic(generated, "", "m/12381/60/0/0")
+	require.NoError(t, err)
+
+	second, err := DeriveKeyFromMnemonic(generated, "", "m/12381/60/0/0")
+	require.NoError(t, err)
+	assert.True(t, first.Scalar().IsEqual(second.Scalar()))
+
+	other, err := DeriveKeyFromMnemonic(generated, "", "m/12381/60/1/0")
+	require.NoError(t, err)
+	assert.False(t, first.Scalar().IsEqual(other.Scalar()))
+}
+
+func TestHD_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := DeriveMasterKey(make([]byte, 31))
+	assert.ErrorIs(t, err, errHDSeedSize)
+
+	for _, path := range []string{"", "12381/60", "m/-1", "m/4294967296", "m//0", "n/0"} {
+		_, err = DeriveKeyFromPath(make([]byte, 32), path)
+		assert.ErrorIs(t, err, errHDInvalidPath, path)
+	}
+
+	key, err := DeriveKeyFromPath(make([]byte, 32), "m")
+	require.NoError(t, err)
+
+	master, err := DeriveMasterKey(make([]byte, 32))
+	require.NoError(t, err)
+	assert.True(t, key.Scalar().IsEqual(master.Scalar()))
+}
@@ -0,0 +1,381 @@
+// Package keystore encrypts BLS private keys with a password into EIP-2335 keystores.
+//
+// The secret is the big-endian scalar of the private key, encrypted by AES-128-CTR with the first half of
+// the key derived from the password, and the checksum is sha256(second half || ciphertext). The public key
+// is stored compressed
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/0xPolygon/bnsnark1/core"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	keystoreVersion = 4
+
+	// ScryptKDF derives the decryption key with scrypt, which is the default
+	ScryptKDF = "scrypt"
+	// PBKDF2KDF derives the decryption key with PBKDF2-HMAC-SHA256
+	PBKDF2KDF = "pbkdf2"
+
+	keystoreScryptN  = 262144
+	keystorePBKDF2C  = 262144
+	keystoreDKLen    = 32
+	keystoreSaltSize = 32
+
+	// the limits of the parameters read from keystores, which would otherwise let a crafted keystore
+	// exhaust the CPU or the memory of the process decrypting it. scrypt uses 128 * n * r bytes
+	keystoreMaxScryptN = 1 << 20
+	keystoreMaxScryptR = 8
+	keystoreMaxScryptP = 4
+	keystoreMaxPBKDF2C = 1 << 22
+	keystoreMaxDKLen   = 64
+)
+
+var (
+	// ErrChecksum is returned when the password is wrong or the keystore is corrupted
+	ErrChecksum = errors.New("keystore checksum mismatch, wrong password")
+
+	errEmptyKey          = errors.New("cannot encrypt an empty private key")
+	errKeystoreVersion   = errors.New("unsupported keystore version")
+	errKeystoreFunction  = errors.New("unsupported keystore function")
+	errKeystoreParams    = errors.New("invalid keystore parameters")
+	errKeystorePublicKey = errors.New("keystore public key does not match the secret")
+)
+
+// Options configures EncryptWithOptions. Zero values select the defaults of EIP-2335
+type Options struct {
+	// KDF is ScryptKDF or PBKDF2KDF
+	KDF string
+	// ScryptN is the cost of scrypt, PBKDF2C the iteration count of PBKDF2
+	ScryptN int
+	PBKDF2C int
+	// Path is the derivation path of the key, if any
+	Path        string
+	Description string
+}
+
+type keystoreJSON struct {
+	Crypto      keystoreCrypto `json:"crypto"`
+	Description string         `json:"description"`
+	PubKey      string         `json:"pubkey"`
+	Path        string         `json:"path"`
+	UUID        string         `json:"uuid"`
+	Version     int            `json:"version"`
+}
+
+type keystoreCrypto struct {
+	KDF      keystoreModule `json:"kdf"`
+	Checksum keystoreModule `json:"checksum"`
+	Cipher   keystoreModule `json:"cipher"`
+}
+
+type keystoreModule struct {
+	Function string                 `json:"function"`
+	Params   map[string]interface{} `json:"params"`
+	Message  string                 `json:"message"`
+}
+
+// Encrypt encrypts the private key with the password into a keystore using scrypt
+func Encrypt(key *core.PrivateKey, password string) ([]byte, error) {
+	return EncryptWithOptions(key, password, nil)
+}
+
+// EncryptWithOptions encrypts the private key with the password into a keystore
+func EncryptWithOptions(key *core.PrivateKey, password string, opts *Options) ([]byte, error) {
+	if key == nil {
+		return nil, errEmptyKey
+	}
+
+	// only an empty key fails to marshal
+	scalar, err := key.MarshalJSON()
+	if err != nil {
+		return nil, errEmptyKey
+	}
+
+	if opts == nil {
+		opts = &Options{}
+	}
+
+	salt := make([]byte, keystoreSaltSize)
+	iv := make([]byte, aes.BlockSize)
+	uuid := make([]byte, 16)
+
+	for _, buf := range [][]byte{salt, iv, uuid} {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+	}
+
+	kdf := keystoreModule{Params: map[string]interface{}{
+		"dklen": keystoreDKLen,
+		"salt":  hex.EncodeToString(salt),
+	}}
+
+	switch opts.KDF {
+	case "", ScryptKDF:
+		kdf.Function = ScryptKDF
+		kdf.Params["n"] = valueOrDefault(opts.ScryptN, keystoreScryptN)
+		kdf.Params["r"] = 8
+		kdf.Params["p"] = 1
+	case PBKDF2KDF:
+		kdf.Function = PBKDF2KDF
+		kdf.Params["c"] = valueOrDefault(opts.PBKDF2C, keystorePBKDF2C)
+		kdf.Params["prf"] = "hmac-sha256"
+	default:
+		return nil, fmt.Errorf("%w: %s", errKeystoreFunction, opts.KDF)
+	}
+
+	decryptionKey, err := deriveKeystoreKey(&kdf, password)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := aes128CTR(decryptionKey[:16], iv, reverseBytes(scalar))
+	if err != nil {
+		return nil, err
+	}
+
+	uuid[6] = uuid[6]&0x0f | 0x40
+	uuid[8] = uuid[8]&0x3f | 0x80
+
+	return json.MarshalIndent(&keystoreJSON{
+		Crypto: keystoreCrypto{
+			KDF: kdf,
+			Checksum: keystoreModule{
+				Function: "sha256",
+				Params:   map[string]interface{}{},
+				Message:  hex.EncodeToString(keystoreChecksum(decryptionKey, ciphertext)),
+			},
+			Cipher: keystoreModule{
+				Function: "aes-128-ctr",
+				Params:   map[string]interface{}{"iv": hex.EncodeToString(iv)},
+				Message:  hex.EncodeToString(ciphertext),
+			},
+		},
+		Description: opts.Description,
+		PubKey:      hex.EncodeToString(key.PublicKey().MarshalCompressed()),
+		Path:        opts.Path,
+		UUID: fmt.Sprintf("%x-%x-%x-%x-%x",
+			uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:]),
+		Version: keystoreVersion,
+	}, "", "  ")
+}
+
+// Decrypt decrypts the private key from the keystore with the password.
+// If the keystore has a public key, it must match the decrypted key
+func Decrypt(data []byte, password string) (*core.PrivateKey, error) {
+	var ks keystoreJSON
+
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return nil, err
+	}
+
+	if ks.Version != keystoreVersion {
+		return nil, errKeystoreVersion
+	}
+
+	if ks.Crypto.Checksum.Function != "sha256" || ks.Crypto.Cipher.Function != "aes-128-ctr" {
+		return nil, errKeystoreFunction
+	}
+
+	ciphertext, err := hex.DecodeString(ks.Crypto.Cipher.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	checksum, err := hex.DecodeString(ks.Crypto.Checksum.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	iv, err := keystoreHexParam(ks.Crypto.Cipher.Params, "iv")
+	if err != nil {
+		return nil, err
+	}
+
+	decryptionKey, err := deriveKeystoreKey(&ks.Crypto.KDF, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare(keystoreChecksum(decryptionKey, ciphertext), checksum) != 1 {
+		return nil, ErrChecksum
+	}
+
+	secret, err := aes128CTR(decryptionKey[:16], iv, ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(secret) != 32 {
+		return nil, fmt.Errorf("%w: secret of %d bytes", errKeystoreParams, len(secret))
+	}
+
+	key, err := core.UnmarshalPrivateKey(reverseBytes(secret))
+	if err != nil {
+		return nil, err
+	}
+
+	if ks.PubKey != "" {
+		pub, err := hex.DecodeString(ks.PubKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.Equal(pub, key.PublicKey().MarshalCompressed()) {
+			return nil, errKeystorePublicKey
+		}
+	}
+
+	return key, nil
+}
+
+func deriveKeystoreKey(kdf *keystoreModule, password string) ([]byte, error) {
+	salt, err := keystoreHexParam(kdf.Params, "salt")
+	if err != nil {
+		return nil, err
+	}
+
+	dkLen, err := keystoreIntParam(kdf.Params, "dklen")
+	if err != nil {
+		return nil, err
+	}
+
+	if dkLen < keystoreDKLen || dkLen > keystoreMaxDKLen {
+		return nil, fmt.Errorf("%w: dklen %d", errKeystoreParams, dkLen)
+	}
+
+	pass := normalizeKeystorePassword(password)
+
+	switch kdf.Function {
+	case ScryptKDF:
+		var n, r, p int
+
+		for name, v := range map[string]*int{"n": &n, "r": &r, "p": &p} {
+			if *v, err = keystoreIntParam(kdf.Params, name); err != nil {
+				return nil, err
+			}
+		}
+
+		if n > keystoreMaxScryptN || r > keystoreMaxScryptR || p > keystoreMaxScryptP {
+			return nil, fmt.Errorf("%w: scrypt n %d, r %d and p %d exceed %d, %d and %d", errKeystoreParams,
+				n, r, p, keystoreMaxScryptN, keystoreMaxScryptR, keystoreMaxScryptP)
+		}
+
+		return scrypt.Key(pass, salt, n, r, p, dkLen)
+	case PBKDF2KDF:
+		if prf, _ := kdf.Params["prf"].(string); prf != "hmac-sha256" {
+			return nil, errKeystoreFunction
+		}
+
+		c, err := keystoreIntParam(kdf.Params, "c")
+		if err != nil {
+			return nil, err
+		}
+
+		if c > keystoreMaxPBKDF2C {
+			return nil, fmt.Errorf("%w: pbkdf2 c %d exceeds %d", errKeystoreParams, c, keystoreMaxPBKDF2C)
+		}
+
+		return pbkdf2.Key(pass, salt, c, dkLen, sha256.New), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errKeystoreFunction, kdf.Function)
+	}
+}
+
+// normalizeKeystorePassword applies NFKD and removes the C0, C1 and Delete control codes
+func normalizeKeystorePassword(password string) []byte {
+	normalized := norm.NFKD.String(password)
+
+	return []byte(strings.Map(func(r rune) rune {
+		if r < 0x20 || (r >= 0x7f && r <= 0x9f) {
+			return -1
+		}
+
+		return r
+	}, normalized))
+}
+
+func keystoreChecksum(decryptionKey, ciphertext []byte) []byte {
+	h := sha256.New()
+
+	_, _ = h.Write(decryptionKey[16:32])
+	_, _ = h.Write(ciphertext)
+
+	return h.Sum(nil)
+}
+
+func aes128CTR(key, iv, in []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(iv) != aes.BlockSize {
+		return nil, errKeystoreParams
+	}
+
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+
+	return out, nil
+}
+
+func keystoreHexParam(params map[string]interface{}, name string) ([]byte, error) {
+	s, ok := params[name].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errKeystoreParams, name)
+	}
+
+	return hex.DecodeString(s)
+}
+
+func keystoreIntParam(params map[string]interface{}, name string) (int, error) {
+	switch v := params[name].(type) {
+	case int:
+		if v > 0 {
+			return v, nil
+		}
+	case float64:
+		// JSON numbers are decoded as float64
+		if v > 0 && v == float64(int(v)) {
+			return int(v), nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %s", errKeystoreParams, name)
+}
+
+func valueOrDefault(v, def int) int {
+	if v == 0 {
+		return def
+	}
+
+	return v
+}
+
+// reverseBytes converts between the big-endian scalars of keystores and the little-endian ones of core
+func reverseBytes(in []byte) []byte {
+	out := make([]byte, len(in))
+
+	for i, b := range in {
+		out[len(in)-1-i] = b
+	}
+
+	return out
+}
@@ -0,0 +1,220 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/0xPolygon/bnsnark1/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	keystoreTestPassword = "𝔱𝔢𝔰𝔱𝔭𝔞𝔰𝔰𝔴𝔬𝔯𝔡🔑"
+	keystoreTestSecret   = "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+	// the public key of the secret on this curve, it replaces the BLS12-381 key of the EIP-2335 vectors
+	keystoreTestPubKey = "eaf64cbd8905327b3b4f47ec362ef09bedfc76f86a7908307f5c2035d7e57900" +
+		"51280811b42fd390a4a769fba9317a2c6f1410a78e884f839a67009bbddfaa8c"
+)
+
+// the test vectors of EIP-2335 with the public key adapted to this curve
+var keystoreTestVectors = []string{
+	`{
+		"crypto": {
+			"kdf": {
+				"function": "scrypt",
+				"params": {
+					"dklen": 32,
+					"n": 262144,
+					"p": 1,
+					"r": 8,
+					"salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"
+				},
+				"message": ""
+			},
+			"checksum": {
+				"function": "sha256",
+				"params": {},
+				"message": "d2217fe5f3e9a1e34581ef8a78f7c9928e436d36dacc5e846690a5581e8ea484"
+			},
+			"cipher": {
+				"function": "aes-128-ctr",
+				"params": {
+					"iv": "264daa3f303d7259501c93d997d84fe6"
+				},
+				"message": "06ae90d55fe0a6e9c5c3bc5b170827b2e5cce3929ed3f116c2811e6366dfe20f"
+			}
+		},
+		"description": "This is a test keystore that uses scrypt to secure the secret.",
+		"pubkey": "` + keystoreTestPubKey + `",
+		"path": "m/12381/60/3141592653/589793238",
+		"uuid": "1d85ae20-35c5-4611-98e8-aa14a633906f",
+		"version": 4
+	}`,
+	`{
+		"crypto": {
+			"kdf": {
+				"function": "pbkdf2",
+				"params": {
+					"dklen": 32,
+					"c": 262144,
+					"prf": "hmac-sha256",
+					"salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"
+				},
+				"message": ""
+			},
+			"checksum": {
+				"function": "sha256",
+				"params": {},
+				"message": "8a9f5d9912ed7e75ea794bc5a89bca5f193721d30868ade6f73043c6ea6febf1"
+			},
+			"cipher": {
+				"function": "aes-128-ctr",
+				"params": {
+					"iv": "264daa3f303d7259501c93d997d84fe6"
+				},
+				"message": "cee03fde2af33149775b7223e7845e4fb2c8ae1792e5f99fe9ecf474cc8c16ad"
+			}
+		},
+		"description": "This is a test keystore that uses PBKDF2 to secure the secret.",
+		"pubkey": "` + keystoreTestPubKey + `",
+		"path": "m/12381/60/0/0",
+		"uuid": "64625def-3331-4eea-ab6f-782f3ed16a83",
+		"version": 4
+	}`,
+}
+
+func TestKeystore_Vectors(t *testing.T) {
+	t.Parallel()
+
+	for _, vector := range keystoreTestVectors {
+		key, err := Decrypt([]byte(vector), keystoreTestPassword)
+		require.NoError(t, err)
+
+		scalar, err := key.MarshalJSON()
+		require.NoError(t, err)
+		assert.Equal(t, keystoreTestSecret, hex.EncodeToString(reverseBytes(scalar)))
+
+		_, err = Decrypt([]byte(vector), "testpassword")
+		assert.ErrorIs(t, err, ErrChecksum)
+	}
+}
+
+func TestKeystore_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	key, err := core.GenerateBlsKey()
+	require.NoError(t, err)
+
+	for _, opts := range []*Options{
+		{KDF: ScryptKDF, ScryptN: 1 << 10, Path: "m/12381/60/0/0", Description: "scrypt"},
+		{KDF: PBKDF2KDF, PBKDF2C: 1 << 10},
+	} {
+		data, err := EncryptWithOptions(key, keystoreTestPassword, opts)
+		require.NoError(t, err)
+
+		var ks keystoreJSON
+
+		require.NoError(t, json.Unmarshal(data, &ks))
+		assert.Equal(t, opts.KDF, ks.Crypto.KDF.Function)
+		assert.Equal(t, opts.Path, ks.Path)
+		assert.Equal(t, hex.EncodeToString(key.PublicKey().MarshalCompressed()), ks.PubKey)
+		assert.Len(t, ks.UUID, 36)
+
+		decrypted, err := Decrypt(data, keystoreTestPassword)
+		require.NoError(t, err)
+		assert.True(t, decrypted.Scalar().IsEqual(key.Scalar()))
+
+		// control codes are removed from the password before the key derivation
+		decrypted, err = Decrypt(data, "\x7f"+keystoreTestPassword+"\n")
+		require.NoError(t, err)
+		assert.True(t, decrypted.Scalar().IsEqual(key.Scalar()))
+
+		_, err = Decrypt(data, "wrong password")
+		assert.ErrorIs(t, err, ErrChecksum)
+	}
+}
+
+func TestKeystore_Invalid(t *testing.T) {
+	t.Parallel()
+
+	key, err := core.GenerateBlsKey()
+	require.NoError(t, err)
+
+	_, err = EncryptWithOptions(key, "", &Options{KDF: "argon2"})
+	assert.ErrorIs(t, err, errKeystoreFunction)
+
+	_, err = Encrypt(&core.PrivateKey{}, "")
+	assert.ErrorIs(t, err, errEmptyKey)
+
+	_, err = Encrypt(nil, "")
+	assert.ErrorIs(t, err, errEmptyKey)
+
+	data, err := EncryptWithOptions(key, "", &Options{KDF: PBKDF2KDF, PBKDF2C: 16})
+	require.NoError(t, err)
+
+	var ks keystoreJSON
+
+	// a valid keystore of another key is rejected
+	require.NoError(t, json.Unmarshal(data, &ks))
+	ks.PubKey = keystoreTestPubKey
+
+	modified, err := json.Marshal(&ks)
+	require.NoError(t, err)
+
+	_, err = Decrypt(modified, "")
+	assert.ErrorIs(t, err, errKeystorePublicKey)
+
+	ks.Version = 3
+
+	modified, err = json.Marshal(&ks)
+	require.NoError(t, err)
+
+	_, err = Decrypt(modified, "")
+	assert.ErrorIs(t, err, errKeystoreVersion)
+
+	// the cost of the key derivation is bounded before deriving
+	ks.Version = keystoreVersion
+
+	for _, params := range []map[string]interface{}{
+		{"c": keystoreMaxPBKDF2C + 1},
+		{"dklen": keystoreMaxDKLen + 1},
+	} {
+		pbkdf2Params := make(map[string]interface{})
+		for name, v := range ks.Crypto.KDF.Params {
+			pbkdf2Params[name] = v
+		}
+
+		for name, v := range params {
+			pbkdf2Params[name] = v
+		}
+
+		invalid := ks
+		invalid.Crypto.KDF.Params = pbkdf2Params
+
+		modified, err = json.Marshal(&invalid)
+		require.NoError(t, err)
+
+		_, err = Decrypt(modified, "")
+		assert.ErrorIs(t, err, errKeystoreParams, params)
+	}
+
+	for _, params := range []map[string]interface{}{
+		{"n": keystoreMaxScryptN * 2, "r": 8, "p": 1},
+		{"n": 2, "r": keystoreMaxScryptR + 1, "p": 1},
+		{"n": 2, "r": 8, "p": keystoreMaxScryptP + 1},
+	} {
+		params["dklen"] = keystoreDKLen
+		params["salt"] = ks.Crypto.KDF.Params["salt"]
+
+		invalid := ks
+		invalid.Crypto.KDF = keystoreModule{Function: ScryptKDF, Params: params}
+
+		modified, err = json.Marshal(&invalid)
+		require.NoError(t, err)
+
+		_, err = Decrypt(modified, "")
+		assert.ErrorIs(t, err, errKeystoreParams, params)
+	}
+}
@@ -0,0 +1,175 @@
+// Package kzg implements the KZG polynomial commitment scheme over BN254 with the primitives of core.
+//
+// The commitment to the polynomial p is p(tau) * g1, computed from the powers of tau of the SRS. The proof
+// of the opening p(z) = y is the commitment to the quotient q(x) = (p(x) - y) / (x - z), verified by
+// e(C - y*g1 + z*proof, g2) == e(proof, tau*g2). An opening at several points is proven by the commitment
+// to (p(x) - I(x)) / Z(x), where I interpolates the values and Z vanishes on the points
+package kzg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/bnsnark1/core"
+	"github.com/0xPolygon/bnsnark1/fft"
+)
+
+var (
+	errPolynomialTooLarge = errors.New("polynomial degree exceeds the srs")
+	errTooManyPoints      = errors.New("number of points exceeds the g2 powers of the srs")
+	errLengthMismatch     = errors.New("points and values must have the same length")
+)
+
+// Commit returns the commitment to the polynomial
+func (s *SRS) Commit(poly Polynomial) (*core.G1, error) {
+	n := poly.degree() + 1
+	if n > len(s.G1) {
+		return nil, fmt.Errorf("%w: %d coefficients, %d powers", errPolynomialTooLarge, n, len(s.G1))
+	}
+
+	commitment := new(core.G1)
+	core.G1MulVec(commitment, s.G1[:n], poly[:n])
+
+	return commitment, nil
+}
+
+// Open evaluates the polynomial at z and returns the value with the proof of the evaluation
+func (s *SRS) Open(poly Polynomial, z *core.Fr) (*core.Fr, *core.G1, error) {
+	y, err := poly.Evaluate(z)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proof, err := s.Commit(poly.divLinear(z))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return y, proof, nil
+}
+
+// Verify checks the proof that the polynomial of the commitment evaluates to y at z
+func (s *SRS) Verify(commitment *core.G1, z, y *core.Fr, proof *core.G1) bool {
+	// C - y*g1 + z*proof
+	lhs, t := new(core.G1), new(core.G1)
+
+	core.G1Mul(t, &s.G1[0], y)
+	core.G1Sub(lhs, commitment, t)
+	core.G1Mul(t, proof, z)
+	core.G1Add(lhs, lhs, t)
+
+	return pairingCheck(lhs, &s.G2[0], proof, &s.G2[1])
+}
+
+// OpenMulti evaluates the polynomial at the distinct points and returns the values
+// with a single proof of all the evaluations
+func (s *SRS) OpenMulti(poly Polynomial, points []core.Fr) ([]core.Fr, *core.G1, error) {
+	if len(points) >= len(s.G2) {
+		return nil, nil, errTooManyPoints
+	}
+
+	values := make([]core.Fr, len(points))
+
+	for i := range points {
+		y, err := poly.Evaluate(&points[i])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		values[i] = *y
+	}
+
+	interpolation, err := interpolate(points, values)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	quotient, _, err := fft.Divide(poly.sub(interpolation), vanishing(points))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proof, err := s.Commit(quotient)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return values, proof, nil
+}
+
+// VerifyMulti checks the proof that the polynomial of the commitment evaluates to values[i] at points[i]
+func (s *SRS) VerifyMulti(commitment *core.G1, points, values []core.Fr, proof *core.G1) (bool, error) {
+	if len(points) != len(values) {
+		return false, errLengthMismatch
+	}
+
+	if len(points) >= len(s.G2) {
+		return false, errTooManyPoints
+	}
+
+	interpolation, err := interpolate(points, values)
+	if err != nil {
+		return false, err
+	}
+
+	interpolationCommitment, err := s.Commit(interpolation)
+	if err != nil {
+		return false, err
+	}
+
+	zero := vanishing(points)
+	zeroCommitment := new(core.G2)
+	core.G2MulVec(zeroCommitment, s.G2[:len(zero)], zero)
+
+	// e(C - I(tau)*g1, g2) == e(proof, Z(tau)*g2)
+	lhs := new(core.G1)
+	core.G1Sub(lhs, commitment, interpolationCommitment)
+
+	return pairingCheck(lhs, &s.G2[0], proof, zeroCommitment), nil
+}
+
+// BatchVerify checks the proofs that the polynomial of commitments[i] evaluates to values[i] at points[i]
+// with two pairings, using a random linear combination of the openings. It does not tell which opening is invalid
+func (s *SRS) BatchVerify(commitments []core.G1, points, values []core.Fr, proofs []core.G1) (bool, error) {
+	n := len(commitments)
+	if len(points) != n || len(values) != n || len(proofs) != n {
+		return false, errLengthMismatch
+	}
+
+	if n == 0 {
+		return true, nil
+	}
+
+	r, err := randomScalars(n)
+	if err != nil {
+		return false, err
+	}
+
+	// sum r_i (C_i - y_i*g1 + z_i*proof_i) and sum r_i proof_i
+	g1s := make([]core.G1, 0, 2*n+1)
+	frs := make([]core.Fr, 0, 2*n+1)
+
+	var sumY, t core.Fr
+
+	for i := 0; i < n; i++ {
+		core.FrMul(&t, &r[i], &points[i])
+
+		g1s = append(g1s, commitments[i], proofs[i])
+		frs = append(frs, r[i], t)
+
+		core.FrMul(&t, &r[i], &values[i])
+		core.FrAdd(&sumY, &sumY, &t)
+	}
+
+	core.FrNeg(&sumY, &sumY)
+
+	g1s = append(g1s, s.G1[0])
+	frs = append(frs, sumY)
+
+	lhs, rhs := new(core.G1), new(core.G1)
+
+	core.G1MulVec(lhs, g1s, frs)
+	core.G1MulVec(rhs, proofs, r)
+
+	return pairingCheck(lhs, &s.G2[0], rhs, &s.G2[1]), nil
+}
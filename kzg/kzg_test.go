@@ -0,0 +1,272 @@
+package kzg
+
+import (
+	"bytes"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/bnsnark1/core"
+	"github.com/0xPolygon/bnsnark1/fft"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDegree = 16
+
+func TestKZG_CommitOpenVerify(t *testing.T) {
+	t.Parallel()
+
+	tau := randomFr(t)
+
+	srs, err := NewSRS(testDegree+1, 2, tau)
+	require.NoError(t, err)
+
+	poly := randomPolynomial(t, testDegree+1)
+
+	commitment, err := srs.Commit(poly)
+	require.NoError(t, err)
+
+	// the commitment is p(tau) * g1
+	value, err := poly.Evaluate(tau)
+	require.NoError(t, err)
+
+	expected := new(core.G1)
+	core.G1Mul(expected, core.GetG1Generator(), value)
+	assert.True(t, commitment.IsEqual(expected))
+
+	z := randomFr(t)
+
+	y, proof, err := srs.Open(poly, z)
+	require.NoError(t, err)
+
+	assert.True(t, srs.Verify(commitment, z, y, proof))
+
+	// a wrong value, point or commitment is rejected
+	wrong := new(core.Fr)
+	core.FrAdd(wrong, y, fft.One())
+
+	assert.False(t, srs.Verify(commitment, z, wrong, proof))
+	assert.False(t, srs.Verify(commitment, wrong, y, proof))
+	assert.False(t, srs.Verify(proof, z, y, proof))
+
+	_, err = srs.Commit(randomPolynomial(t, testDegree+2))
+	assert.ErrorIs(t, err, errPolynomialTooLarge)
+
+	// the zero polynomial commits to the identity
+	commitment, err = srs.Commit(Polynomial{})
+	require.NoError(t, err)
+	assert.True(t, commitment.IsZero())
+}
+
+func TestKZG_MultiOpening(t *testing.T) {
+	t.Parallel()
+
+	srs, err := GenerateSRS(testDegree+1, 5)
+	require.NoError(t, err)
+
+	poly := randomPolynomial(t, testDegree+1)
+
+	commitment, err := srs.Commit(poly)
+	require.NoError(t, err)
+
+	points := []core.Fr{*randomFr(t), *randomFr(t), *randomFr(t), *randomFr(t)}
+
+	values, proof, err := srs.OpenMulti(poly, points)
+	require.NoError(t, err)
+
+	for i := range points {
+		y, err := poly.Evaluate(&points[i])
+		require.NoError(t, err)
+		assert.True(t, y.IsEqual(&values[i]))
+	}
+
+	ok, err := srs.VerifyMulti(commitment, points, values, proof)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	core.FrAdd(&values[2], &values[2], fft.One())
+
+	ok, err = srs.VerifyMulti(commitment, points, values, proof)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = srs.OpenMulti(poly, append(points, *randomFr(t)))
+	assert.ErrorIs(t, err, errTooManyPoints)
+
+	_, _, err = srs.OpenMulti(poly, []core.Fr{points[0], points[0]})
+	assert.ErrorIs(t, err, errDuplicatePoint)
+
+	// a single point is the same as Open
+	values, proof, err = srs.OpenMulti(poly, points[:1])
+	require.NoError(t, err)
+	assert.True(t, srs.Verify(commitment, &points[0], &values[0], proof))
+}
+
+func TestKZG_BatchVerify(t *testing.T) {
+	t.Parallel()
+
+	srs, err := GenerateSRS(testDegree+1, 2)
+	require.NoError(t, err)
+
+	const n = 4
+
+	commitments := make([]core.G1, n)
+	points := make([]core.Fr, n)
+	values := make([]core.Fr, n)
+	proofs := make([]core.G1, n)
+
+	for i := 0; i < n; i++ {
+		poly := randomPolynomial(t, testDegree+1-i)
+
+		commitment, err := srs.Commit(poly)
+		require.NoError(t, err)
+
+		points[i] = *randomFr(t)
+
+		y, proof, err := srs.Open(poly, &points[i])
+		require.NoError(t, err)
+
+		commitments[i], values[i], proofs[i] = *commitment, *y, *proof
+	}
+
+	ok, err := srs.BatchVerify(commitments, points, values, proofs)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	proofs[0], proofs[1] = proofs[1], proofs[0]
+
+	ok, err = srs.BatchVerify(commitments, points, values, proofs)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = srs.BatchVerify(commitments, points[1:], values, proofs)
+	assert.ErrorIs(t, err, errLengthMismatch)
+}
+
+func TestKZG_SRSSerialization(t *testing.T) {
+	t.Parallel()
+
+	srs, err := GenerateSRS(8, 3)
+	require.NoError(t, err)
+	require.NoError(t, srs.Validate())
+
+	var buf bytes.Buffer
+
+	_, err = srs.WriteTo(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, 8+8*64+3*128, buf.Len())
+
+	read, err := ReadSRS(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Len(t, read.G1, 8)
+	require.Len(t, read.G2, 3)
+
+	for i := range srs.G1 {
+		assert.True(t, srs.G1[i].IsEqual(&read.G1[i]))
+	}
+
+	// powers of different taus are detected
+	other, err := GenerateSRS(8, 3)
+	require.NoError(t, err)
+
+	srs.G1[5] = other.G1[5]
+	assert.ErrorIs(t, srs.Validate(), errInconsistentSRS)
+
+	srs.G1[5] = read.G1[5]
+	srs.G2[2] = other.G2[2]
+	assert.ErrorIs(t, srs.Validate(), errInconsistentSRS)
+
+	srs.G2 = other.G2
+	assert.ErrorIs(t, srs.Validate(), errInconsistentSRS)
+
+	_, err = ReadSRS(bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+	assert.Error(t, err)
+
+	_, err = NewSRS(1, 1, fft.One())
+	assert.ErrorIs(t, err, errInvalidSRSSize)
+}
+
+func TestKZG_ReadPtau(t *testing.T) {
+	t.Parallel()
+
+	// tau2_power2.ptau is laid out as the output of snarkjs powersoftau new for a power of 2, with every section,
+	// but its powers are those of tau = 2, alpha = 3 and beta = 5 instead of 1 so that they can be checked
+	data, err := os.ReadFile(filepath.Join("testdata", "tau2_power2.ptau"))
+	require.NoError(t, err)
+
+	// the generator (1, 2) starts the powers in G1, with x in Montgomery form 2^256 mod p
+	const g1x = "9d0d8fc58d435dd33d0bc7f528eb780a2c4679786fa36e662fdf079ac1770a0e"
+
+	assert.Equal(t, g1x, hex.EncodeToString(data[80:112]))
+
+	var tau core.Fr
+
+	tau.SetInt64(2)
+
+	expected, err := NewSRS(7, 4, &tau)
+	require.NoError(t, err)
+
+	srs, err := ReadPtau(bytes.NewReader(data), 7, 4)
+	require.NoError(t, err)
+	require.Len(t, srs.G1, 7)
+	require.Len(t, srs.G2, 4)
+
+	for i := range srs.G1 {
+		assert.True(t, expected.G1[i].IsEqual(&srs.G1[i]), i)
+	}
+
+	for i := range srs.G2 {
+		assert.True(t, expected.G2[i].IsEqual(&srs.G2[i]), i)
+	}
+
+	// fewer powers may be read
+	srs, err = ReadPtau(bytes.NewReader(data), 3, 2)
+	require.NoError(t, err)
+	assert.Len(t, srs.G1, 3)
+	assert.Len(t, srs.G2, 2)
+
+	// a file of power 2 holds 7 powers in G1 and 4 in G2
+	_, err = ReadPtau(bytes.NewReader(data), 8, 4)
+	assert.Error(t, err)
+
+	_, err = ReadPtau(bytes.NewReader(data), 7, 5)
+	assert.Error(t, err)
+
+	_, err = ReadPtau(bytes.NewReader(data[:600]), 7, 4)
+	assert.Error(t, err)
+
+	tampered := append([]byte{}, data...)
+	tampered[0] = 'x'
+
+	_, err = ReadPtau(bytes.NewReader(tampered), 7, 4)
+	assert.ErrorIs(t, err, errPtauFormat)
+
+	// a coordinate of the fourth power in G1 is changed
+	copy(tampered, data)
+	tampered[80+3*64]++
+
+	_, err = ReadPtau(bytes.NewReader(tampered), 7, 4)
+	assert.Error(t, err)
+}
+
+func randomFr(t *testing.T) *core.Fr {
+	t.Helper()
+
+	fr := new(core.Fr)
+	require.True(t, fr.SetByCSPRNG())
+
+	return fr
+}
+
+func randomPolynomial(t *testing.T, n int) Polynomial {
+	t.Helper()
+
+	poly := make(Polynomial, n)
+	for i := range poly {
+		poly[i] = *randomFr(t)
+	}
+
+	return poly
+}
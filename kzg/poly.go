@@ -0,0 +1,136 @@
+package kzg
+
+import (
+	"errors"
+
+	"github.com/0xPolygon/bnsnark1/core"
+	"github.com/0xPolygon/bnsnark1/fft"
+)
+
+var errDuplicatePoint = errors.New("duplicate evaluation point")
+
+// Polynomial is given by its coefficients in Fr, from the constant term up
+type Polynomial []core.Fr
+
+// Evaluate returns the value of the polynomial at x
+func (p Polynomial) Evaluate(x *core.Fr) (*core.Fr, error) {
+	y := new(core.Fr)
+
+	if err := core.FrEvaluatePolynomial(y, p, x); err != nil {
+		return nil, err
+	}
+
+	return y, nil
+}
+
+// degree returns the degree of the polynomial, -1 for the zero polynomial
+func (p Polynomial) degree() int {
+	for i := len(p) - 1; i >= 0; i-- {
+		if !p[i].IsZero() {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// sub returns p - q
+func (p Polynomial) sub(q Polynomial) Polynomial {
+	n := len(p)
+	if len(q) > n {
+		n = len(q)
+	}
+
+	res := make(Polynomial, n)
+	copy(res, p)
+
+	for i := range q {
+		core.FrSub(&res[i], &res[i], &q[i])
+	}
+
+	return res
+}
+
+// mulLinear returns p * (x - z)
+func (p Polynomial) mulLinear(z *core.Fr) Polynomial {
+	res := make(Polynomial, len(p)+1)
+
+	var t core.Fr
+
+	for i := range p {
+		// res[i+1] += p[i], res[i] -= z * p[i]
+		core.FrAdd(&res[i+1], &res[i+1], &p[i])
+		core.FrMul(&t, z, &p[i])
+		core.FrSub(&res[i], &res[i], &t)
+	}
+
+	return res
+}
+
+// divLinear returns the quotient of p / (x - z) by synthetic division, the remainder p(z) is dropped
+func (p Polynomial) divLinear(z *core.Fr) Polynomial {
+	if len(p) < 2 {
+		return Polynomial{}
+	}
+
+	res := make(Polynomial, len(p)-1)
+	res[len(res)-1] = p[len(p)-1]
+
+	for i := len(res) - 1; i > 0; i-- {
+		core.FrMul(&res[i-1], &res[i], z)
+		core.FrAdd(&res[i-1], &res[i-1], &p[i])
+	}
+
+	return res
+}
+
+// vanishing returns the polynomial (x - points[0]) * ... * (x - points[n-1])
+func vanishing(points []core.Fr) Polynomial {
+	res := Polynomial{*fft.One()}
+
+	for i := range points {
+		res = res.mulLinear(&points[i])
+	}
+
+	return res
+}
+
+// interpolate returns the polynomial of degree below len(points) going through (points[i], values[i])
+func interpolate(points, values []core.Fr) (Polynomial, error) {
+	res := make(Polynomial, len(points))
+	all := vanishing(points)
+
+	var denom, t core.Fr
+
+	for i := range points {
+		// the Lagrange basis polynomial of points[i] is all / (x - points[i]) / prod_{j != i} (points[i] - points[j])
+		basis := all.divLinear(&points[i])
+
+		denom = *fft.One()
+
+		for j := range points {
+			if j == i {
+				continue
+			}
+
+			core.FrSub(&t, &points[i], &points[j])
+
+			if t.IsZero() {
+				return nil, errDuplicatePoint
+			}
+
+			core.FrMul(&denom, &denom, &t)
+		}
+
+		core.FrDiv(&t, &values[i], &denom)
+
+		for k := range basis {
+			var c core.Fr
+
+			core.FrMul(&c, &basis[k], &t)
+			core.FrAdd(&res[k], &res[k], &c)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,234 @@
+package kzg
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/0xPolygon/bnsnark1/core"
+)
+
+// ptau files of snarkjs start with the magic, the version and the number of sections, each section
+// with its id and size. Integers are little-endian and the points are affine with their coordinates
+// in Montgomery form, G2 coordinates with the real part first
+const (
+	ptauMagic   = "ptau"
+	ptauVersion = 1
+
+	ptauHeaderSection = 1
+	ptauTauG1Section  = 2
+	ptauTauG2Section  = 3
+
+	ptauFpSize = 32
+)
+
+var errPtauFormat = errors.New("unsupported ptau file")
+
+var (
+	ptauFieldOrder, _ = new(big.Int).SetString(core.GetFieldOrder(), 10)
+	// ptauMontgomery converts from Montgomery form, it is the inverse of 2^256 modulo the field order
+	ptauMontgomery = new(big.Int).ModInverse(new(big.Int).Lsh(big.NewInt(1), 8*ptauFpSize), ptauFieldOrder)
+)
+
+// ReadPtau reads the first n1 powers of tau in G1 and n2 in G2 from a .ptau file of snarkjs, such as the
+// output of the Perpetual Powers of Tau and Hermez ceremonies. A file of power p holds 2^(p+1) - 1 powers
+// in G1 and 2^p in G2. Every point is checked to be in its subgroup and the powers are checked with Validate
+func ReadPtau(r io.Reader, n1, n2 int) (*SRS, error) {
+	if n1 < 1 || n2 < 2 {
+		return nil, errInvalidSRSSize
+	}
+
+	if n1 > maxSRSSize || n2 > maxSRSSize {
+		return nil, fmt.Errorf("srs of %d and %d powers is too large", n1, n2)
+	}
+
+	br := bufio.NewReader(r)
+
+	var header [12]byte
+
+	if _, err := io.ReadFull(br, header[:]); err != nil {
+		return nil, err
+	}
+
+	if string(header[:4]) != ptauMagic || binary.LittleEndian.Uint32(header[4:8]) != ptauVersion {
+		return nil, fmt.Errorf("%w: bad magic or version", errPtauFormat)
+	}
+
+	srs := &SRS{
+		G1: make([]core.G1, 0, minInt(uint32(n1), srsReadChunk)),
+		G2: make([]core.G2, 0, minInt(uint32(n2), srsReadChunk)),
+	}
+	power := -1
+	sections := binary.LittleEndian.Uint32(header[8:])
+
+	// the sections following the powers of tau are not read
+	for ; sections > 0 && (len(srs.G1) < n1 || len(srs.G2) < n2); sections-- {
+		var section [12]byte
+
+		if _, err := io.ReadFull(br, section[:]); err != nil {
+			return nil, err
+		}
+
+		id, size := binary.LittleEndian.Uint32(section[:4]), binary.LittleEndian.Uint64(section[4:])
+		sr := &io.LimitedReader{R: br, N: int64(size)}
+
+		var err error
+
+		switch id {
+		case ptauHeaderSection:
+			power, err = readPtauHeader(sr)
+			if err == nil && (uint64(n1) > 1<<(power+1)-1 || uint64(n2) > 1<<power) {
+				err = fmt.Errorf("ptau file of power %d has fewer than %d and %d powers", power, n1, n2)
+			}
+		case ptauTauG1Section, ptauTauG2Section:
+			if power < 0 {
+				return nil, fmt.Errorf("%w: powers before the header", errPtauFormat)
+			}
+
+			if (id == ptauTauG1Section && len(srs.G1) > 0) || (id == ptauTauG2Section && len(srs.G2) > 0) {
+				return nil, fmt.Errorf("%w: duplicate section %d", errPtauFormat, id)
+			}
+
+			if id == ptauTauG1Section {
+				err = readPtauG1(sr, srs, n1)
+			} else {
+				err = readPtauG2(sr, srs, n2)
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		// the rest of the section, or the whole of a section that is not needed, is skipped
+		if _, err := io.Copy(io.Discard, sr); err != nil {
+			return nil, err
+		}
+
+		if sr.N > 0 {
+			return nil, io.ErrUnexpectedEOF
+		}
+	}
+
+	if len(srs.G1) < n1 || len(srs.G2) < n2 {
+		return nil, fmt.Errorf("%w: missing powers of tau", errPtauFormat)
+	}
+
+	if err := srs.Validate(); err != nil {
+		return nil, err
+	}
+
+	return srs, nil
+}
+
+// readPtauHeader reads the header section and returns the power of the file, which must be of BN254
+func readPtauHeader(r io.Reader) (int, error) {
+	var header [4 + ptauFpSize + 4]byte
+
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return 0, err
+	}
+
+	if binary.LittleEndian.Uint32(header[:4]) != ptauFpSize {
+		return 0, fmt.Errorf("%w: field elements of %d bytes", errPtauFormat, binary.LittleEndian.Uint32(header[:4]))
+	}
+
+	if ptauInt(header[4:4+ptauFpSize]).Cmp(ptauFieldOrder) != 0 {
+		return 0, fmt.Errorf("%w: not a BN254 file", errPtauFormat)
+	}
+
+	power := binary.LittleEndian.Uint32(header[4+ptauFpSize:])
+	if power > 30 {
+		return 0, fmt.Errorf("%w: power %d", errPtauFormat, power)
+	}
+
+	return int(power), nil
+}
+
+func readPtauG1(r io.Reader, srs *SRS, n int) error {
+	raw := make([]byte, 2*ptauFpSize)
+
+	for i := 0; i < n; i++ {
+		if _, err := io.ReadFull(r, raw); err != nil {
+			return err
+		}
+
+		evm, err := ptauToEVM(raw, 0, 1)
+		if err != nil {
+			return err
+		}
+
+		p, err := core.G1FromEVM(evm)
+		if err != nil {
+			return fmt.Errorf("g1 power %d: %w", i, err)
+		}
+
+		if err := core.ValidateG1(p); err != nil {
+			return fmt.Errorf("g1 power %d: %w", i, err)
+		}
+
+		srs.G1 = append(srs.G1, *p)
+	}
+
+	return nil
+}
+
+func readPtauG2(r io.Reader, srs *SRS, n int) error {
+	raw := make([]byte, 4*ptauFpSize)
+
+	for i := 0; i < n; i++ {
+		if _, err := io.ReadFull(r, raw); err != nil {
+			return err
+		}
+
+		// the EVM encoding puts the imaginary part first
+		evm, err := ptauToEVM(raw, 1, 0, 3, 2)
+		if err != nil {
+			return err
+		}
+
+		p, err := core.G2FromEVM(evm)
+		if err != nil {
+			return fmt.Errorf("g2 power %d: %w", i, err)
+		}
+
+		if err := core.ValidateG2(p); err != nil {
+			return fmt.Errorf("g2 power %d: %w", i, err)
+		}
+
+		srs.G2 = append(srs.G2, *p)
+	}
+
+	return nil
+}
+
+// ptauToEVM converts the coordinates in Montgomery form to the big-endian EVM encoding, in the given order
+func ptauToEVM(raw []byte, order ...int) ([]byte, error) {
+	res := make([]byte, 0, len(raw))
+
+	for _, i := range order {
+		v := ptauInt(raw[i*ptauFpSize : (i+1)*ptauFpSize])
+		if v.Cmp(ptauFieldOrder) >= 0 {
+			return nil, fmt.Errorf("%w: coordinate is not below the field order", errPtauFormat)
+		}
+
+		v.Mul(v, ptauMontgomery).Mod(v, ptauFieldOrder)
+		res = append(res, v.FillBytes(make([]byte, ptauFpSize))...)
+	}
+
+	return res, nil
+}
+
+// ptauInt reads the little-endian integer
+func ptauInt(raw []byte) *big.Int {
+	be := append([]byte{}, raw...)
+
+	for i, j := 0, len(be)-1; i < j; i, j = i+1, j-1 {
+		be[i], be[j] = be[j], be[i]
+	}
+
+	return new(big.Int).SetBytes(be)
+}
@@ -0,0 +1,267 @@
+package kzg
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/0xPolygon/bnsnark1/core"
+	"github.com/0xPolygon/bnsnark1/fft"
+)
+
+const (
+	g1Size = 64
+	g2Size = 128
+
+	// maxSRSSize bounds the number of powers read by ReadSRS, which allocates at most
+	// srsReadChunk powers ahead of the data actually read
+	maxSRSSize   = 1 << 28
+	srsReadChunk = 1 << 12
+)
+
+var (
+	errInvalidSRSSize  = errors.New("srs needs at least one power in G1 and two in G2")
+	errInconsistentSRS = errors.New("srs powers are not consecutive powers of the same tau")
+	errRandomScalar    = errors.New("error generating random scalar")
+)
+
+// SRS is the structured reference string: the powers tau^i * g1 and tau^i * g2 of a secret tau.
+// Polynomials of degree below len(G1) can be committed to and openings at up to len(G2)-1 points
+// can be batched into one proof
+type SRS struct {
+	G1 []core.G1
+	G2 []core.G2
+}
+
+// NewSRS computes the srs of the secret tau with n1 powers in G1 and n2 in G2. Anyone knowing tau can
+// forge openings, so it is only meant for tests
+func NewSRS(n1, n2 int, tau *core.Fr) (*SRS, error) {
+	if n1 < 1 || n2 < 2 {
+		return nil, errInvalidSRSSize
+	}
+
+	srs := &SRS{G1: make([]core.G1, n1), G2: make([]core.G2, n2)}
+	power := fft.One()
+
+	for i := 0; i < n1 || i < n2; i++ {
+		if i < n1 {
+			core.G1Mul(&srs.G1[i], core.GetG1Generator(), power)
+		}
+
+		if i < n2 {
+			core.G2Mul(&srs.G2[i], core.GetG2Generator(), power)
+		}
+
+		core.FrMul(power, power, tau)
+	}
+
+	// the powers reveal tau as much as tau itself
+	power.Clear()
+
+	return srs, nil
+}
+
+// GenerateSRS computes the srs of a random tau, which is discarded. The srs is only as trustworthy
+// as the process running the function
+func GenerateSRS(n1, n2 int) (*SRS, error) {
+	tau := new(core.Fr)
+	if !tau.SetByCSPRNG() {
+		return nil, errRandomScalar
+	}
+
+	srs, err := NewSRS(n1, n2, tau)
+	tau.Clear()
+
+	return srs, err
+}
+
+// Validate checks that the srs starts with the generators and that its powers are consecutive powers
+// of the same tau, using one random linear combination of each group
+func (s *SRS) Validate() error {
+	if len(s.G1) < 1 || len(s.G2) < 2 {
+		return errInvalidSRSSize
+	}
+
+	if !s.G1[0].IsEqual(core.GetG1Generator()) || !s.G2[0].IsEqual(core.GetG2Generator()) {
+		return errInconsistentSRS
+	}
+
+	// e(sum r_i G1[i+1], g2) == e(sum r_i G1[i], tau g2)
+	if len(s.G1) > 1 {
+		lo, hi, err := randomCombinationG1(s.G1)
+		if err != nil {
+			return err
+		}
+
+		if !pairingCheck(lo, &s.G2[1], hi, &s.G2[0]) {
+			return errInconsistentSRS
+		}
+	}
+
+	// e(g1, sum r_i G2[i+1]) == e(tau g1, sum r_i G2[i]) needs tau g1
+	if len(s.G2) > 2 {
+		if len(s.G1) < 2 {
+			return errInvalidSRSSize
+		}
+
+		lo, hi, err := randomCombinationG2(s.G2)
+		if err != nil {
+			return err
+		}
+
+		if !pairingCheck(&s.G1[1], lo, &s.G1[0], hi) {
+			return errInconsistentSRS
+		}
+	}
+
+	// tau g1 and tau g2 are the same power
+	if len(s.G1) > 1 && !pairingCheck(&s.G1[1], &s.G2[0], &s.G1[0], &s.G2[1]) {
+		return errInconsistentSRS
+	}
+
+	return nil
+}
+
+// WriteTo writes the number of powers in G1 and G2 as big-endian uint32 followed by the
+// uncompressed powers, see core.G1ToBytes and core.G2ToBytes
+func (s *SRS) WriteTo(w io.Writer) (int64, error) {
+	buf := make([]byte, 8, 8+len(s.G1)*g1Size+len(s.G2)*g2Size)
+
+	binary.BigEndian.PutUint32(buf[:4], uint32(len(s.G1)))
+	binary.BigEndian.PutUint32(buf[4:], uint32(len(s.G2)))
+
+	for i := range s.G1 {
+		buf = append(buf, core.G1ToBytes(&s.G1[i])...)
+	}
+
+	for i := range s.G2 {
+		buf = append(buf, core.G2ToBytes(&s.G2[i])...)
+	}
+
+	n, err := w.Write(buf)
+
+	return int64(n), err
+}
+
+// ReadSRS reads the srs written by WriteTo, see ReadPtau for the .ptau files of setup ceremonies.
+// Every point is checked to be in its subgroup and the powers are checked with Validate
+func ReadSRS(r io.Reader) (*SRS, error) {
+	var header [8]byte
+
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	n1, n2 := binary.BigEndian.Uint32(header[:4]), binary.BigEndian.Uint32(header[4:])
+	if n1 > maxSRSSize || n2 > maxSRSSize {
+		return nil, fmt.Errorf("srs of %d and %d powers is too large", n1, n2)
+	}
+
+	srs := &SRS{G1: make([]core.G1, 0, minInt(n1, srsReadChunk)), G2: make([]core.G2, 0, minInt(n2, srsReadChunk))}
+	raw := make([]byte, g2Size)
+
+	for i := 0; i < int(n1); i++ {
+		if _, err := io.ReadFull(r, raw[:g1Size]); err != nil {
+			return nil, err
+		}
+
+		p, err := core.G1FromBytes(raw[:g1Size])
+		if err != nil {
+			return nil, err
+		}
+
+		if err := core.ValidateG1(p); err != nil {
+			return nil, fmt.Errorf("g1 power %d: %w", i, err)
+		}
+
+		srs.G1 = append(srs.G1, *p)
+	}
+
+	for i := 0; i < int(n2); i++ {
+		if _, err := io.ReadFull(r, raw); err != nil {
+			return nil, err
+		}
+
+		p, err := core.G2FromBytes(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := core.ValidateG2(p); err != nil {
+			return nil, fmt.Errorf("g2 power %d: %w", i, err)
+		}
+
+		srs.G2 = append(srs.G2, *p)
+	}
+
+	if err := srs.Validate(); err != nil {
+		return nil, err
+	}
+
+	return srs, nil
+}
+
+// randomCombinationG1 returns sum r_i points[i] and sum r_i points[i+1]
+func randomCombinationG1(points []core.G1) (*core.G1, *core.G1, error) {
+	r, err := randomScalars(len(points) - 1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lo, hi := new(core.G1), new(core.G1)
+
+	core.G1MulVec(lo, points[:len(points)-1], r)
+	core.G1MulVec(hi, points[1:], r)
+
+	return lo, hi, nil
+}
+
+// randomCombinationG2 returns sum r_i points[i] and sum r_i points[i+1]
+func randomCombinationG2(points []core.G2) (*core.G2, *core.G2, error) {
+	r, err := randomScalars(len(points) - 1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lo, hi := new(core.G2), new(core.G2)
+
+	core.G2MulVec(lo, points[:len(points)-1], r)
+	core.G2MulVec(hi, points[1:], r)
+
+	return lo, hi, nil
+}
+
+func minInt(a uint32, b int) int {
+	if int(a) < b {
+		return int(a)
+	}
+
+	return b
+}
+
+func randomScalars(n int) ([]core.Fr, error) {
+	r := make([]core.Fr, n)
+
+	for i := range r {
+		if !r[i].SetByCSPRNG() {
+			return nil, errRandomScalar
+		}
+	}
+
+	return r, nil
+}
+
+// pairingCheck checks e(a1, b1) == e(a2, b2)
+func pairingCheck(a1 *core.G1, b1 *core.G2, a2 *core.G1, b2 *core.G2) bool {
+	var neg core.G1
+
+	core.G1Neg(&neg, a2)
+
+	e := new(core.GT)
+
+	core.MillerLoopVec(e, []core.G1{*a1, neg}, []core.G2{*b1, *b2})
+	core.FinalExp(e, e)
+
+	return e.IsOne()
+}
@@ -0,0 +1,203 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/0xPolygon/bnsnark1/core"
+)
+
+// DefaultClientTimeout is the timeout of the requests of a client created without an http client
+const DefaultClientTimeout = 10 * time.Second
+
+// Client calls the API of the Server
+type Client struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewClient creates the client of the server at the url, e.g. http://127.0.0.1:9000.
+// A client with DefaultClientTimeout is used if httpClient is nil
+func NewClient(serverURL string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultClientTimeout}
+	}
+
+	return &Client{url: strings.TrimSuffix(serverURL, "/"), httpClient: httpClient}
+}
+
+// Keys returns the public keys of the server by their ids
+func (c *Client) Keys(ctx context.Context) (map[string]*core.PublicKey, error) {
+	var res keysJSON
+
+	if err := c.do(ctx, http.MethodGet, keysPath, nil, &res); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*core.PublicKey, len(res.Keys))
+
+	for _, key := range res.Keys {
+		publicKey, err := decodePublicKey(key.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		keys[key.ID] = publicKey
+	}
+
+	return keys, nil
+}
+
+// PublicKey returns the public key of the key with the id
+func (c *Client) PublicKey(ctx context.Context, id string) (*core.PublicKey, error) {
+	var res keyJSON
+
+	if err := c.do(ctx, http.MethodGet, keysPath+"/"+url.PathEscape(id), nil, &res); err != nil {
+		return nil, err
+	}
+
+	return decodePublicKey(res.PublicKey)
+}
+
+// Sign signs the message for the duty with the key with the id. The signature is not verified
+func (c *Client) Sign(ctx context.Context, id string, duty Duty, message []byte) (*core.Signature, error) {
+	var res signResponseJSON
+
+	req := signRequestJSON{Duty: duty, Message: hex.EncodeToString(message)}
+
+	if err := c.do(ctx, http.MethodPost, keysPath+"/"+url.PathEscape(id)+signPath, req, &res); err != nil {
+		return nil, err
+	}
+
+	raw, err := hex.DecodeString(res.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.UnmarshalSignature(raw)
+}
+
+// Signer returns the remote signer of the key with the id
+func (c *Client) Signer(ctx context.Context, id string) (*RemoteSigner, error) {
+	publicKey, err := c.PublicKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RemoteSigner{client: c, id: id, publicKey: publicKey, ctx: context.Background()}, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, res interface{}) error {
+	var reqBody bytes.Buffer
+
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.url+path, &reqBody)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errRes errorJSON
+
+		if err := json.NewDecoder(resp.Body).Decode(&errRes); err != nil {
+			return fmt.Errorf("remote signer: %s", resp.Status)
+		}
+
+		if codeErr, ok := errorCodes[errRes.Code]; ok {
+			return fmt.Errorf("%w: %s", codeErr, errRes.Error)
+		}
+
+		return fmt.Errorf("remote signer: %s: %s", resp.Status, errRes.Error)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(res)
+}
+
+// RemoteSigner signs with a key of the server. It implements Signer once it has a duty.
+// Its requests are bound by its context and by the timeout of the http client
+type RemoteSigner struct {
+	client    *Client
+	id        string
+	publicKey *core.PublicKey
+	duty      *Duty
+	ctx       context.Context
+}
+
+// WithDuty returns a copy of the signer signing for the duty
+func (r *RemoteSigner) WithDuty(duty Duty) *RemoteSigner {
+	res := *r
+	res.duty = &duty
+
+	return &res
+}
+
+// WithContext returns a copy of the signer whose requests are canceled with the context
+func (r *RemoteSigner) WithContext(ctx context.Context) *RemoteSigner {
+	res := *r
+	res.ctx = ctx
+
+	return &res
+}
+
+// ID returns the id of the key
+func (r *RemoteSigner) ID() string {
+	return r.id
+}
+
+// PublicKey returns the public key of the key
+func (r *RemoteSigner) PublicKey() *core.PublicKey {
+	return r.publicKey
+}
+
+// Sign signs the message for the duty of the signer and verifies the returned signature
+func (r *RemoteSigner) Sign(message []byte) (*core.Signature, error) {
+	if r.duty == nil {
+		return nil, errMissingDuty
+	}
+
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	signature, err := r.client.Sign(ctx, r.id, *r.duty, message)
+	if err != nil {
+		return nil, err
+	}
+
+	if !signature.Verify(r.publicKey, message) {
+		return nil, errInvalidResponse
+	}
+
+	return signature, nil
+}
+
+func decodePublicKey(value string) (*core.PublicKey, error) {
+	raw, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return core.UnmarshalPublicKey(raw)
+}
@@ -0,0 +1,228 @@
+package signer
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/0xPolygon/bnsnark1/keystore"
+)
+
+const (
+	keysPath        = "/v1/keys"
+	signPath        = "/sign"
+	maxRequestBytes = 1 << 20
+)
+
+// error codes of the API, so the client returns the same errors as the server
+var errorCodes = map[string]error{
+	"unknown_key":         ErrUnknownKey,
+	"slashing_conflict":   ErrSlashingConflict,
+	"slashing_regression": ErrSlashingRegression,
+}
+
+type keyJSON struct {
+	ID        string `json:"id"`
+	PublicKey string `json:"publicKey"`
+}
+
+type keysJSON struct {
+	Keys []keyJSON `json:"keys"`
+}
+
+type signRequestJSON struct {
+	Duty
+	Message string `json:"message"`
+}
+
+type signResponseJSON struct {
+	Signature string `json:"signature"`
+}
+
+type errorJSON struct {
+	Error string `json:"error"`
+	Code  string `json:"code,omitempty"`
+}
+
+// Server signs with its keys over HTTP and records every signature in the slashing protection database.
+//
+//	GET  /v1/keys           lists the keys
+//	GET  /v1/keys/{id}      returns the public key of the key
+//	POST /v1/keys/{id}/sign signs {"height", "round", "type", "message"} with the key
+//
+// Public keys, messages and signatures are hex encoded, keys are identified by KeyID.
+// The API has no authentication or TLS, anyone who reaches the server signs with its keys,
+// so it must only listen on the loopback or another trusted interface
+type Server struct {
+	keys map[string]Signer
+	ids  []string
+	db   *SlashingDB
+}
+
+// NewServer creates the server signing with the keys
+func NewServer(keys []Signer, db *SlashingDB) *Server {
+	s := &Server{
+		keys: make(map[string]Signer, len(keys)),
+		ids:  make([]string, 0, len(keys)),
+		db:   db,
+	}
+
+	for _, key := range keys {
+		id := KeyID(key.PublicKey())
+		if _, ok := s.keys[id]; !ok {
+			s.ids = append(s.ids, id)
+		}
+
+		s.keys[id] = key
+	}
+
+	sort.Strings(s.ids)
+
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+
+	switch {
+	case path == keysPath && r.Method == http.MethodGet:
+		s.handleKeys(w)
+	case strings.HasPrefix(path, keysPath+"/") && strings.HasSuffix(path, signPath) && r.Method == http.MethodPost:
+		s.handleSign(w, r, strings.TrimSuffix(strings.TrimPrefix(path, keysPath+"/"), signPath))
+	case strings.HasPrefix(path, keysPath+"/") && r.Method == http.MethodGet:
+		s.handleKey(w, strings.TrimPrefix(path, keysPath+"/"))
+	default:
+		writeError(w, http.StatusNotFound, errors.New("not found"))
+	}
+}
+
+func (s *Server) handleKeys(w http.ResponseWriter) {
+	res := keysJSON{Keys: make([]keyJSON, len(s.ids))}
+
+	for i, id := range s.ids {
+		res.Keys[i] = newKeyJSON(id, s.keys[id])
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (s *Server) handleKey(w http.ResponseWriter, id string) {
+	key, ok := s.keys[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("%w: %s", ErrUnknownKey, id))
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newKeyJSON(id, key))
+}
+
+func (s *Server) handleSign(w http.ResponseWriter, r *http.Request, id string) {
+	key, ok := s.keys[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("%w: %s", ErrUnknownKey, id))
+
+		return
+	}
+
+	var req signRequestJSON
+
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	message, err := hex.DecodeString(strings.TrimPrefix(req.Message, "0x"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+
+		return
+	}
+
+	// the duty is recorded before signing, a crash in between loses a signature but never double signs
+	if err := s.db.CheckAndRecord(id, req.Duty, message); err != nil {
+		if errors.Is(err, ErrSlashingConflict) || errors.Is(err, ErrSlashingRegression) {
+			writeError(w, http.StatusConflict, err)
+		} else {
+			writeError(w, http.StatusInternalServerError, err)
+		}
+
+		return
+	}
+
+	signature, err := key.Sign(message)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	raw, err := signature.Marshal()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, signResponseJSON{Signature: hex.EncodeToString(raw)})
+}
+
+// LoadKeystores decrypts all the *.json keystores in the directory with the password
+func LoadKeystores(dir, password string) ([]Signer, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]Signer, len(paths))
+
+	for i, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := keystore.Decrypt(data, password)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		keys[i] = key
+	}
+
+	return keys, nil
+}
+
+func newKeyJSON(id string, key Signer) keyJSON {
+	return keyJSON{ID: id, PublicKey: hex.EncodeToString(key.PublicKey().Marshal())}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	res := errorJSON{Error: err.Error()}
+
+	for code, codeErr := range errorCodes {
+		if errors.Is(err, codeErr) {
+			res.Code = code
+		}
+	}
+
+	writeJSON(w, status, res)
+}
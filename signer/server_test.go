@@ -0,0 +1,147 @@
+package signer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygon/bnsnark1/core"
+	"github.com/0xPolygon/bnsnark1/keystore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer_RemoteSigner(t *testing.T) {
+	t.Parallel()
+
+	keys, err := core.CreateRandomBlsKeys(2)
+	require.NoError(t, err)
+
+	client := newTestClient(t, keys)
+	ctx := context.Background()
+
+	remoteKeys, err := client.Keys(ctx)
+	require.NoError(t, err)
+	require.Len(t, remoteKeys, 2)
+
+	for _, key := range keys {
+		publicKey, ok := remoteKeys[KeyID(key.PublicKey())]
+		require.True(t, ok)
+		assert.Equal(t, key.PublicKey().Marshal(), publicKey.Marshal())
+	}
+
+	remote, err := client.Signer(ctx, KeyID(keys[0].PublicKey()))
+	require.NoError(t, err)
+
+	// local and remote keys are used the same way
+	local, err := sign(keys[0], []byte("block"))
+	require.NoError(t, err)
+
+	_, err = sign(remote, []byte("block"))
+	assert.ErrorIs(t, err, errMissingDuty)
+
+	signature, err := sign(remote.WithDuty(Duty{Height: 1, Round: 0, Type: 1}), []byte("block"))
+	require.NoError(t, err)
+	assert.True(t, signature.Verify(keys[0].PublicKey(), []byte("block")))
+
+	localRaw, err := local.Marshal()
+	require.NoError(t, err)
+
+	raw, err := signature.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, localRaw, raw)
+
+	_, err = sign(remote.WithDuty(Duty{Height: 1, Round: 0, Type: 1}), []byte("other block"))
+	assert.ErrorIs(t, err, ErrSlashingConflict)
+
+	_, err = sign(remote.WithDuty(Duty{Height: 0, Round: 3, Type: 1}), []byte("block"))
+	assert.ErrorIs(t, err, ErrSlashingRegression)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+
+	_, err = sign(remote.WithDuty(Duty{Height: 2, Round: 0, Type: 1}).WithContext(canceled), []byte("block"))
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = client.Signer(ctx, "00")
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	_, err = client.Sign(ctx, "00", Duty{}, []byte("block"))
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestServer_InvalidRequests(t *testing.T) {
+	t.Parallel()
+
+	keys, err := core.CreateRandomBlsKeys(1)
+	require.NoError(t, err)
+
+	client := newTestClient(t, keys)
+	id := KeyID(keys[0].PublicKey())
+
+	assert.Error(t, client.do(context.Background(), http.MethodPost, keysPath+"/"+id+signPath,
+		map[string]string{"message": "not hex"}, nil))
+	assert.Error(t, client.do(context.Background(), http.MethodPost, keysPath+"/"+id+signPath,
+		map[string]string{"unknown": ""}, nil))
+	assert.Error(t, client.do(context.Background(), http.MethodDelete, keysPath+"/"+id, nil, nil))
+}
+
+func TestClient_DefaultTimeout(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("http://127.0.0.1:9000/", nil)
+
+	assert.Equal(t, "http://127.0.0.1:9000", client.url)
+	assert.Equal(t, DefaultClientTimeout, client.httpClient.Timeout)
+}
+
+func TestLoadKeystores(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	key, err := core.GenerateBlsKey()
+	require.NoError(t, err)
+
+	encrypted, err := keystore.EncryptWithOptions(key, "password", &keystore.Options{KDF: keystore.PBKDF2KDF, PBKDF2C: 2})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "key.json"), encrypted, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "password.txt"), []byte("password"), 0o600))
+
+	keys, err := LoadKeystores(dir, "password")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, KeyID(key.PublicKey()), KeyID(keys[0].PublicKey()))
+
+	_, err = LoadKeystores(dir, "wrong")
+	assert.ErrorIs(t, err, keystore.ErrChecksum)
+}
+
+func newTestClient(t *testing.T, keys []*core.PrivateKey) *Client {
+	t.Helper()
+
+	db, err := OpenSlashingDB(filepath.Join(t.TempDir(), "slashing.db"))
+	require.NoError(t, err)
+
+	signers := make([]Signer, len(keys))
+	for i, key := range keys {
+		signers[i] = key
+	}
+
+	server := httptest.NewServer(NewServer(signers, db))
+
+	t.Cleanup(func() {
+		server.Close()
+		db.Close()
+	})
+
+	return NewClient(server.URL, server.Client())
+}
+
+func sign(signer Signer, message []byte) (*core.Signature, error) {
+	return signer.Sign(message)
+}
@@ -0,0 +1,63 @@
+// Package signer keeps the private keys of validators out of the node process.
+//
+// The Server holds the keys, loaded from EIP-2335 keystores, and signs over an HTTP/JSON API.
+// Every signature is requested for a duty, the height, round and type of the consensus message, which
+// the slashing protection database records per key before signing. A different message for a recorded
+// duty or a duty older than the latest signed one of its type is refused, so a key never signs
+// conflicting messages even after a restart of the node or the server.
+//
+// The Client talks to the server and its RemoteSigner implements Signer like core.PrivateKey does,
+// so the node signs the same way with a local or a remote key.
+//
+// The server does not authenticate its clients, it must only listen on an interface reachable by the node alone
+package signer
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygon/bnsnark1/core"
+)
+
+var (
+	// ErrSlashingConflict is returned when a different message was already signed for the duty
+	ErrSlashingConflict = errors.New("conflicting message already signed for the duty")
+	// ErrSlashingRegression is returned when a later duty of the same type was already signed
+	ErrSlashingRegression = errors.New("later duty already signed")
+	// ErrUnknownKey is returned when the server has no key with the given id
+	ErrUnknownKey = errors.New("unknown key")
+
+	errMissingDuty     = errors.New("remote signer has no duty")
+	errInvalidResponse = errors.New("invalid signature returned by the remote signer")
+)
+
+// Signer signs messages with a private key. core.PrivateKey and RemoteSigner implement it
+type Signer interface {
+	PublicKey() *core.PublicKey
+	Sign(message []byte) (*core.Signature, error)
+}
+
+var (
+	_ Signer = (*core.PrivateKey)(nil)
+	_ Signer = (*RemoteSigner)(nil)
+)
+
+// MessageType is the type of the consensus message, its values are defined by the consensus
+type MessageType uint8
+
+// Duty identifies the consensus message being signed
+type Duty struct {
+	Height uint64      `json:"height"`
+	Round  uint64      `json:"round"`
+	Type   MessageType `json:"type"`
+}
+
+// KeyID returns the id of the key, the hex encoded compressed public key
+func KeyID(publicKey *core.PublicKey) string {
+	return hex.EncodeToString(publicKey.MarshalCompressed())
+}
+
+func (d Duty) String() string {
+	return fmt.Sprintf("height %d round %d type %d", d.Height, d.Round, d.Type)
+}
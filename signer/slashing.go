@@ -0,0 +1,82 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const (
+	slashingDBMode    = 0o600
+	slashingDBTimeout = time.Second
+)
+
+// SlashingDB records the duties signed by every key. Each key has a bucket holding a bucket per
+// message type, whose keys are the big-endian height and round and whose values are the sha256 of
+// the signed message
+type SlashingDB struct {
+	db *bolt.DB
+}
+
+// OpenSlashingDB opens or creates the slashing protection database at the path.
+// The file is locked, so only one server can use it
+func OpenSlashingDB(path string) (*SlashingDB, error) {
+	db, err := bolt.Open(path, slashingDBMode, &bolt.Options{Timeout: slashingDBTimeout})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SlashingDB{db: db}, nil
+}
+
+// Close closes the database
+func (s *SlashingDB) Close() error {
+	return s.db.Close()
+}
+
+// CheckAndRecord records that the key signs the message for the duty. It fails with ErrSlashingConflict
+// if another message was signed for the duty and with ErrSlashingRegression if a later duty of the same
+// type was signed. Signing the same message for the same duty again is allowed
+func (s *SlashingDB) CheckAndRecord(keyID string, duty Duty, message []byte) error {
+	root := sha256.Sum256(message)
+	key := dutyKey(duty)
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		keyBucket, err := tx.CreateBucketIfNotExists([]byte(keyID))
+		if err != nil {
+			return err
+		}
+
+		bucket, err := keyBucket.CreateBucketIfNotExists([]byte{byte(duty.Type)})
+		if err != nil {
+			return err
+		}
+
+		if signed := bucket.Get(key); signed != nil {
+			if !bytes.Equal(signed, root[:]) {
+				return fmt.Errorf("%w: %s", ErrSlashingConflict, duty)
+			}
+
+			return nil
+		}
+
+		if last, _ := bucket.Cursor().Last(); last != nil && bytes.Compare(last, key) > 0 {
+			return fmt.Errorf("%w: %s", ErrSlashingRegression, duty)
+		}
+
+		return bucket.Put(key, root[:])
+	})
+}
+
+func dutyKey(duty Duty) []byte {
+	key := make([]byte, 16)
+
+	binary.BigEndian.PutUint64(key[:8], duty.Height)
+	binary.BigEndian.PutUint64(key[8:], duty.Round)
+
+	return key
+}
@@ -0,0 +1,45 @@
+package signer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlashingDB_CheckAndRecord(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "slashing.db")
+
+	db, err := OpenSlashingDB(path)
+	require.NoError(t, err)
+
+	duty := Duty{Height: 10, Round: 1, Type: 1}
+
+	require.NoError(t, db.CheckAndRecord("a", duty, []byte("block")))
+	// the same message can be signed again
+	require.NoError(t, db.CheckAndRecord("a", duty, []byte("block")))
+
+	assert.ErrorIs(t, db.CheckAndRecord("a", duty, []byte("other block")), ErrSlashingConflict)
+
+	// earlier duties of the same type are refused, other types and keys are independent
+	assert.ErrorIs(t, db.CheckAndRecord("a", Duty{Height: 10, Round: 0, Type: 1}, []byte("block")), ErrSlashingRegression)
+	assert.ErrorIs(t, db.CheckAndRecord("a", Duty{Height: 9, Round: 5, Type: 1}, []byte("block")), ErrSlashingRegression)
+	assert.NoError(t, db.CheckAndRecord("a", Duty{Height: 9, Round: 5, Type: 2}, []byte("block")))
+	assert.NoError(t, db.CheckAndRecord("b", duty, []byte("other block")))
+	assert.NoError(t, db.CheckAndRecord("a", Duty{Height: 10, Round: 2, Type: 1}, []byte("other block")))
+
+	// the records survive reopening the database
+	require.NoError(t, db.Close())
+
+	db, err = OpenSlashingDB(path)
+	require.NoError(t, err)
+
+	defer db.Close()
+
+	assert.ErrorIs(t, db.CheckAndRecord("a", duty, []byte("another block")), ErrSlashingConflict)
+	assert.ErrorIs(t, db.CheckAndRecord("a", Duty{Height: 10, Round: 0, Type: 1}, []byte("block")), ErrSlashingRegression)
+	assert.NoError(t, db.CheckAndRecord("a", Duty{Height: 10, Round: 2, Type: 1}, []byte("other block")))
+}
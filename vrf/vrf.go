@@ -0,0 +1,104 @@
+// Package vrf implements a verifiable random function on BLS signatures.
+//
+// The proof of the input alpha is the BLS signature of alpha under the dedicated domain separation tag
+// CiphersuiteID and the output beta is SHA-256(CiphersuiteID || 0x03 || proof || 0x00), where the proof is
+// serialized uncompressed. Only the holder of the private key can compute beta, and anyone can verify it
+// against the public key.
+//
+// Uniqueness: G1 has prime order and the pairing is non-degenerate, so for a public key in the G2 subgroup
+// other than the identity exactly one point of G1 satisfies the verification equation of alpha. Verify
+// rejects identity public keys and proofs that are not in G1 and hashes the canonical serialization of the
+// point, so even a prover choosing its key maliciously cannot produce two valid outputs for the same input.
+// The output is unpredictable as long as the private key is secret and uniformly random
+package vrf
+
+import (
+	"crypto/sha256"
+	"errors"
+
+	"github.com/0xPolygon/bnsnark1/core"
+)
+
+// CiphersuiteID identifies the VRF and is the domain separation tag of its proofs
+const CiphersuiteID = "BLS_VRF_BN254G1_XMD:SHA-256_FT_RO_"
+
+const (
+	proofToHashFront = 0x03
+	proofToHashBack  = 0x00
+)
+
+var (
+	// ErrInvalidProof is returned by Verify when the proof does not match the public key and the input
+	ErrInvalidProof = errors.New("invalid vrf proof")
+
+	errNilKey = errors.New("key must not be nil")
+)
+
+var scheme = core.MustNewScheme(CiphersuiteID, []byte(CiphersuiteID), core.HashToG107WithDST)
+
+// Prove computes the proof and the 32 bytes output of the input alpha with the private key
+func Prove(sk *core.PrivateKey, alpha []byte) (*core.Signature, []byte, error) {
+	if sk == nil {
+		return nil, nil, errNilKey
+	}
+
+	proof, err := scheme.Sign(sk, alpha)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	beta, err := ProofToHash(proof)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return proof, beta, nil
+}
+
+// Verify checks the proof of the input alpha against the public key and returns the output.
+// The public key and the proof are validated, see the package documentation for why this matters.
+// The arguments are not modified
+func Verify(pk *core.PublicKey, alpha []byte, proof *core.Signature) ([]byte, error) {
+	if pk == nil || proof == nil {
+		return nil, ErrInvalidProof
+	}
+
+	// Marshal normalizes the point it is called on, so it is called on clones. The round trips through
+	// the checked unmarshalling validate the points and the decoded points are used from then on
+	decodedPk, err := core.UnmarshalPublicKey(pk.Clone().Marshal())
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := proof.Clone().Marshal()
+	if err != nil {
+		return nil, ErrInvalidProof
+	}
+
+	decodedProof, err := core.UnmarshalSignature(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if !scheme.Verify(decodedProof, decodedPk, alpha) {
+		return nil, ErrInvalidProof
+	}
+
+	return ProofToHash(decodedProof)
+}
+
+// ProofToHash returns the output of the proof without verifying it
+func ProofToHash(proof *core.Signature) ([]byte, error) {
+	raw, err := proof.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha256.New()
+	_, _ = h.Write([]byte(CiphersuiteID))
+	_, _ = h.Write([]byte{proofToHashFront})
+	_, _ = h.Write(raw)
+	_, _ = h.Write([]byte{proofToHashBack})
+
+	return h.Sum(nil), nil
+}
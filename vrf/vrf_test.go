@@ -0,0 +1,139 @@
+package vrf
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/0xPolygon/bnsnark1/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVRF_Vectors(t *testing.T) {
+	t.Parallel()
+
+	// the private keys are hex, the proofs compressed
+	cases := []struct {
+		sk    string
+		alpha string
+		proof string
+		beta  string
+	}{
+		{
+			sk:    "1",
+			alpha: "",
+			proof: "7c63993f1f8f72b34328bad388f8fd0965a34f97ad5573ff5d46443b0169e42e",
+			beta:  "88b72682e204814816693c9a63da5d7551ba71ec95e812cd4191d5d57a178f36",
+		},
+		{
+			sk:    "1",
+			alpha: "abc",
+			proof: "59d8afef522b665161a355d9081a1a8036e1e9a2b94b1875386a87d678c3180c",
+			beta:  "c8e932042b68392759aff07d92454005f8ad84d1eb08523032ee7b9a4a574570",
+		},
+		{
+			sk:    "2a",
+			alpha: "block 100",
+			proof: "73d894db4d166e74d330b62d78c1d2bb8e2da67549f83efd445462bc852d8329",
+			beta:  "ca2bf5bcfc2182de04b4a3a9462d9b252cef570b2cb50523c52fc6451093ed7b",
+		},
+		{
+			sk:    "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			alpha: "",
+			proof: "c39d400d52769bcc86ff86caf3a6fd140404b80b2e25ae859e65011d88f1bda7",
+			beta:  "f8edbeb09c8cbd6fc8d784a12be9052ba8530db8bac9cda12fd352d0cf9345b0",
+		},
+		{
+			sk:    "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			alpha: "block 100",
+			proof: "c3e7c2883fa7d3ceb182d4cca9b0b6455f8af877b7897ee73f15f0a5f019d38b",
+			beta:  "ceca0692ed09a28b81ce33e406df6f48c2b52d66614b377264d7a20774059f64",
+		},
+	}
+
+	for _, c := range cases {
+		fr := new(core.Fr)
+		require.NoError(t, fr.SetString(c.sk, 16))
+
+		sk := core.NewPrivateKey(fr)
+
+		proof, beta, err := Prove(sk, []byte(c.alpha))
+		require.NoError(t, err)
+
+		raw, err := proof.MarshalCompressed()
+		require.NoError(t, err)
+		assert.Equal(t, c.proof, hex.EncodeToString(raw))
+		assert.Equal(t, c.beta, hex.EncodeToString(beta))
+
+		raw, err = hex.DecodeString(c.proof)
+		require.NoError(t, err)
+
+		decoded, err := core.UnmarshalSignature(raw)
+		require.NoError(t, err)
+
+		verified, err := Verify(sk.PublicKey(), []byte(c.alpha), decoded)
+		require.NoError(t, err)
+		assert.Equal(t, c.beta, hex.EncodeToString(verified))
+	}
+}
+
+func TestVRF_Invalid(t *testing.T) {
+	t.Parallel()
+
+	keys, err := core.CreateRandomBlsKeys(2)
+	require.NoError(t, err)
+
+	proof, beta, err := Prove(keys[0], []byte("abc"))
+	require.NoError(t, err)
+
+	// the output is unique, proving again gives the same one
+	_, again, err := Prove(keys[0], []byte("abc"))
+	require.NoError(t, err)
+	assert.Equal(t, beta, again)
+
+	_, err = Verify(keys[0].PublicKey(), []byte("abd"), proof)
+	assert.ErrorIs(t, err, ErrInvalidProof)
+
+	_, err = Verify(keys[1].PublicKey(), []byte("abc"), proof)
+	assert.ErrorIs(t, err, ErrInvalidProof)
+
+	// a BLS signature of the same input is not a proof
+	signature, err := keys[0].Sign([]byte("abc"))
+	require.NoError(t, err)
+
+	_, err = Verify(keys[0].PublicKey(), []byte("abc"), signature)
+	assert.ErrorIs(t, err, ErrInvalidProof)
+
+	// with the identity as public key the identity would be a proof of every input
+	_, err = Verify(core.NewPublicKey(new(core.G2)), []byte("abc"), core.AggregateSignatures(nil))
+	assert.ErrorIs(t, err, core.ErrIdentityPoint)
+
+	_, err = Verify(keys[0].PublicKey(), []byte("abc"), nil)
+	assert.ErrorIs(t, err, ErrInvalidProof)
+
+	_, _, err = Prove(nil, []byte("abc"))
+	assert.Error(t, err)
+}
+
+func TestVRF_VerifyKeepsProof(t *testing.T) {
+	t.Parallel()
+
+	keys, err := core.CreateRandomBlsKeys(2)
+	require.NoError(t, err)
+
+	proofs := make([]*core.Signature, len(keys))
+
+	for i, key := range keys {
+		proofs[i], _, err = Prove(key, []byte("abc"))
+		require.NoError(t, err)
+	}
+
+	// the sum of the proofs is a proof for the sum of the keys, with its point not normalized
+	proof := proofs[0].Aggregate(proofs[1])
+	pk := keys[0].PublicKey().Aggregate(keys[1].PublicKey())
+	before := proof.String()
+
+	_, err = Verify(pk, []byte("abc"), proof)
+	require.NoError(t, err)
+	assert.Equal(t, before, proof.String())
+}